@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	ccloudapi "github.com/riferrei/ccloud-sdk-go"
+)
+
+const apiKeysURI = "/api_keys"
+
+// The Confluent Cloud API behind ccloud-sdk-go, called
+// directly for what the client doesn't offer.
+var (
+	ccloudAPIURL        = "https://confluent.cloud/api"
+	ccloudAPIHTTPClient = &http.Client{Timeout: 30 * time.Second}
+)
+
+// apiKeyListing is an API Key as listed by Confluent
+// Cloud, which never includes the secret of the key.
+type apiKeyListing struct {
+	ID            int                  `json:"id"`
+	Key           string               `json:"key"`
+	EnvironmentID string               `json:"account_id"`
+	Clusters      []*ccloudapi.Cluster `json:"logical_clusters"`
+	OwnerID       int                  `json:"user_id"`
+	Description   string               `json:"description"`
+	Created       *time.Time           `json:"created"`
+}
+
+type apiKeyList struct {
+	APIKeys []*apiKeyListing `json:"api_keys"`
+}
+
+// createdAPIKey is an API Key as returned on creation,
+// the only time Confluent Cloud includes its secret.
+type createdAPIKey struct {
+	apiKeyListing
+	Secret string `json:"secret"`
+}
+
+type apiKeyRequestBody struct {
+	ID            int                  `json:"id,omitempty"`
+	EnvironmentID string               `json:"account_id"`
+	Clusters      []*ccloudapi.Cluster `json:"logical_clusters"`
+	OwnerID       int                  `json:"user_id,omitempty"`
+	Description   *string              `json:"description,omitempty"`
+}
+
+type apiKeyRequest struct {
+	APIKey *apiKeyRequestBody `json:"api_key"`
+}
+
+type apiKeyResponse struct {
+	APIKey *createdAPIKey `json:"api_key"`
+}
+
+// listAPIKeys lists the API Keys of an environment, optionally
+// narrowed to a cluster. The client only offers looking up a
+// single key by its value through the same endpoint.
+func listAPIKeys(ctx context.Context, session *ccloudapi.Session, environmentID, clusterID string) ([]*apiKeyListing, error) {
+	return queryAPIKeys(ctx, session, environmentID, clusterID, "")
+}
+
+// readAPIKey looks up an API Key of a cluster by its value, as
+// the client does, but keeps the fields the client leaves out.
+func readAPIKey(ctx context.Context, session *ccloudapi.Session, environmentID, clusterID, key string) (*apiKeyListing, error) {
+	apiKeys, err := queryAPIKeys(ctx, session, environmentID, clusterID, key)
+	if err != nil || len(apiKeys) == 0 {
+		return nil, err
+	}
+	return apiKeys[0], nil
+}
+
+func queryAPIKeys(ctx context.Context, session *ccloudapi.Session, environmentID, clusterID, key string) ([]*apiKeyListing, error) {
+	query := url.Values{}
+	query.Set("account_id", environmentID)
+	if len(clusterID) > 0 {
+		query.Set("cluster_id", clusterID)
+	}
+	if len(key) > 0 {
+		query.Set("key", key)
+	}
+	apiKeys := new(apiKeyList)
+	err := ccloudAPIRequest(ctx, session, "GET", apiKeysURI+"?"+query.Encode(), nil, apiKeys)
+	if err != nil {
+		return nil, err
+	}
+	return apiKeys.APIKeys, nil
+}
+
+// createAPIKey creates an API Key for a cluster. Unlike the client,
+// which always creates keys for the user it is logged in as, the key
+// can be owned by another user or a service account. An owner ID of
+// zero leaves the key to the user of the session.
+func createAPIKey(ctx context.Context, session *ccloudapi.Session, environmentID, clusterID string, ownerID int, description string) (*createdAPIKey, error) {
+	request := &apiKeyRequest{
+		APIKey: &apiKeyRequestBody{
+			EnvironmentID: environmentID,
+			Clusters:      []*ccloudapi.Cluster{{ID: clusterID}},
+			OwnerID:       ownerID,
+			Description:   &description,
+		},
+	}
+	response := new(apiKeyResponse)
+	if err := ccloudAPIRequest(ctx, session, "POST", apiKeysURI, request, response); err != nil {
+		return nil, err
+	}
+	if response.APIKey == nil {
+		return nil, fmt.Errorf("No API Key was returned by Confluent Cloud")
+	}
+	return response.APIKey, nil
+}
+
+// updateAPIKey changes the description of an API Key, the
+// only part of a key that can change once it is created.
+func updateAPIKey(ctx context.Context, session *ccloudapi.Session, environmentID, clusterID string, id int, description string) error {
+	request := &apiKeyRequest{
+		APIKey: &apiKeyRequestBody{
+			ID:            id,
+			EnvironmentID: environmentID,
+			Clusters:      []*ccloudapi.Cluster{{ID: clusterID}},
+			Description:   &description,
+		},
+	}
+	return ccloudAPIRequest(ctx, session, "PUT",
+		apiKeysURI+"/"+strconv.Itoa(id), request, nil)
+}
+
+// deleteAPIKey deletes an API Key by its ID, sending the same
+// body as the client since the endpoint still expects it.
+func deleteAPIKey(ctx context.Context, session *ccloudapi.Session, environmentID, clusterID string, id int) error {
+	request := &apiKeyRequest{
+		APIKey: &apiKeyRequestBody{
+			ID:            id,
+			EnvironmentID: environmentID,
+			Clusters:      []*ccloudapi.Cluster{{ID: clusterID}},
+		},
+	}
+	return ccloudAPIRequest(ctx, session, "DELETE",
+		apiKeysURI+"/"+strconv.Itoa(id), request, nil)
+}
+
+// ccloudAPIRequest sends the request body, if any, as JSON
+// and decodes the response into the result, if any.
+func ccloudAPIRequest(ctx context.Context, session *ccloudapi.Session, method, uri string, body, result interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewBuffer(bodyBytes)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, ccloudAPIURL+uri, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Cookie", "auth_token="+session.AuthToken)
+	resp, err := ccloudAPIHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// Errors look like the ones of the client, so isNotFound applies
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		errorResp := new(ccloudapi.ErrorResponse)
+		if json.Unmarshal(respBytes, errorResp) == nil && len(errorResp.Error.Message) > 0 {
+			return fmt.Errorf("%s: %s", resp.Status, errorResp.Error.Message)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(respBytes, result)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ccloudapi "github.com/riferrei/ccloud-sdk-go"
+)
+
+const (
+	testEnvironmentID = "env-abc12"
+	testUserID        = 1000
+	testAuthToken     = "token"
+)
+
+// fakeCCloudAPI stands in for the API Key endpoints of
+// Confluent Cloud, keeping keys in memory.
+type fakeCCloudAPI struct {
+	*httptest.Server
+	mutex   sync.Mutex
+	nextID  int
+	apiKeys map[int]*createdAPIKey
+}
+
+// newFakeCCloudAPI starts the fake and points the provider at it
+// until the test is over, returning the session to use with it.
+func newFakeCCloudAPI(t *testing.T) (*fakeCCloudAPI, *ccloudapi.Session) {
+	fake := &fakeCCloudAPI{nextID: 1, apiKeys: map[int]*createdAPIKey{}}
+	fake.Server = httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	defaultURL := ccloudAPIURL
+	ccloudAPIURL = fake.URL
+	t.Cleanup(func() {
+		ccloudAPIURL = defaultURL
+		fake.Close()
+	})
+	return fake, &ccloudapi.Session{AuthToken: testAuthToken,
+		User: ccloudapi.User{ID: testUserID}}
+}
+
+func (f *fakeCCloudAPI) apiKey(id int) *createdAPIKey {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.apiKeys[id]
+}
+
+func (f *fakeCCloudAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if cookie, err := r.Cookie("auth_token"); err != nil || cookie.Value != testAuthToken {
+		writeCCloudAPIError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if r.URL.Path == apiKeysURI {
+		switch r.Method {
+		case "GET":
+			f.listAPIKeys(w, r)
+		case "POST":
+			f.createAPIKey(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, apiKeysURI+"/"))
+	apiKey, ok := f.apiKeys[id]
+	if err != nil || !ok {
+		writeCCloudAPIError(w, http.StatusNotFound, "API Key not found")
+		return
+	}
+	switch r.Method {
+	case "PUT":
+		request := new(apiKeyRequest)
+		json.NewDecoder(r.Body).Decode(request)
+		if request.APIKey != nil && request.APIKey.Description != nil {
+			apiKey.Description = *request.APIKey.Description
+		}
+		writeCCloudAPIJSON(w, http.StatusOK, &apiKeyResponse{
+			APIKey: &createdAPIKey{apiKeyListing: apiKey.apiKeyListing}})
+	case "DELETE":
+		delete(f.apiKeys, apiKey.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeCCloudAPI) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	apiKeys := &apiKeyList{APIKeys: []*apiKeyListing{}}
+	for id := 1; id < f.nextID; id++ {
+		apiKey, ok := f.apiKeys[id]
+		if !ok || apiKey.EnvironmentID != query.Get("account_id") {
+			continue
+		}
+		if key := query.Get("key"); len(key) > 0 && key != apiKey.Key {
+			continue
+		}
+		if clusterID := query.Get("cluster_id"); len(clusterID) > 0 &&
+			(len(apiKey.Clusters) == 0 || apiKey.Clusters[0].ID != clusterID) {
+			continue
+		}
+		listing := apiKey.apiKeyListing
+		apiKeys.APIKeys = append(apiKeys.APIKeys, &listing)
+	}
+	writeCCloudAPIJSON(w, http.StatusOK, apiKeys)
+}
+
+func (f *fakeCCloudAPI) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	request := new(apiKeyRequest)
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil || request.APIKey == nil {
+		writeCCloudAPIError(w, http.StatusBadRequest, "Invalid API Key")
+		return
+	}
+	ownerID := request.APIKey.OwnerID
+	if ownerID == 0 {
+		ownerID = testUserID
+	}
+	description := ""
+	if request.APIKey.Description != nil {
+		description = *request.APIKey.Description
+	}
+	created := time.Date(2020, 8, 20, 14, 0, 0, 0, time.UTC)
+	apiKey := &createdAPIKey{
+		apiKeyListing: apiKeyListing{
+			ID:            f.nextID,
+			Key:           fmt.Sprintf("KEY%013d", f.nextID),
+			EnvironmentID: request.APIKey.EnvironmentID,
+			Clusters:      request.APIKey.Clusters,
+			OwnerID:       ownerID,
+			Description:   description,
+			Created:       &created,
+		},
+		Secret: fmt.Sprintf("secret-%d", f.nextID),
+	}
+	f.apiKeys[apiKey.ID] = apiKey
+	f.nextID++
+	writeCCloudAPIJSON(w, http.StatusCreated, &apiKeyResponse{APIKey: apiKey})
+}
+
+func writeCCloudAPIJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeCCloudAPIError(w http.ResponseWriter, statusCode int, message string) {
+	writeCCloudAPIJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    statusCode,
+			"message": message,
+		},
+	})
+}
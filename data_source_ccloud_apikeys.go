@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAPIKeys() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAPIKeysRead,
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"api_keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"environment_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"owner_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAPIKeysRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	session := meta.(*providerMeta).session
+	environmentID := data.Get("environment_id").(string)
+	clusterID := data.Get("cluster_id").(string)
+	ownerID := data.Get("owner_id").(string)
+	apiKeys, err := listAPIKeys(ctx, session, environmentID, clusterID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(apiKeys, func(i, j int) bool {
+		return apiKeys[i].ID < apiKeys[j].ID
+	})
+	ids := []string{}
+	keys := []string{}
+	matches := []map[string]interface{}{}
+	for _, apiKey := range apiKeys {
+		clusterIDs := []string{}
+		for _, cluster := range apiKey.Clusters {
+			clusterIDs = append(clusterIDs, cluster.ID)
+		}
+		// Keys without clusters, such as Cloud keys, are
+		// not scoped to the cluster used as a filter.
+		if len(clusterID) > 0 && !containsString(clusterIDs, clusterID) {
+			continue
+		}
+		if len(ownerID) > 0 && ownerID != strconv.Itoa(apiKey.OwnerID) {
+			continue
+		}
+		createdAt := ""
+		if apiKey.Created != nil {
+			createdAt = apiKey.Created.UTC().Format(time.RFC3339)
+		}
+		id := strconv.Itoa(apiKey.ID)
+		ids = append(ids, id)
+		keys = append(keys, apiKey.Key)
+		matches = append(matches, map[string]interface{}{
+			"id":             id,
+			"key":            apiKey.Key,
+			"environment_id": apiKey.EnvironmentID,
+			"cluster_ids":    clusterIDs,
+			"owner_id":       strconv.Itoa(apiKey.OwnerID),
+			"description":    apiKey.Description,
+			"created_at":     createdAt,
+		})
+	}
+	if len(clusterID) > 0 {
+		data.SetId(environmentID + "/" + clusterID)
+	} else {
+		data.SetId(environmentID)
+	}
+	data.Set("ids", ids)
+	data.Set("keys", keys)
+	data.Set("api_keys", matches)
+	return diags
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ccloudapi "github.com/riferrei/ccloud-sdk-go"
+)
+
+func TestDataSourceAPIKeysRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != apiKeysURI || r.URL.Query().Get("account_id") != "env-abc12" {
+			http.NotFound(w, r)
+			return
+		}
+		if cookie, err := r.Cookie("auth_token"); err != nil || cookie.Value != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":{"code":401,"message":"Unauthorized"}}`)
+			return
+		}
+		// Keys as returned by the endpoint, with all of their fields
+		fmt.Fprint(w, `{"api_keys":[
+			{"id":2,"key":"KEY2","secret":"","hashed_secret":"","hash_function":"bcrypt","sasl_mechanism":"PLAIN","user_id":12345,"deactivated":false,"created":"2020-08-21T09:30:00.123456Z","modified":"2020-08-21T09:30:00.123456Z","description":"orders-service","logical_clusters":[{"id":"lkc-abc12","type":"kafka"}],"account_id":"env-abc12","service_account":true},
+			{"id":1,"key":"KEY1","secret":"","hashed_secret":"","hash_function":"bcrypt","sasl_mechanism":"PLAIN","user_id":67890,"deactivated":false,"created":"2020-08-20T14:00:00Z","modified":"2020-08-20T14:00:00Z","description":"","logical_clusters":[{"id":"lkc-abc12","type":"kafka"}],"account_id":"env-abc12","service_account":false},
+			{"id":3,"key":"KEY3","secret":"","hashed_secret":"","hash_function":"bcrypt","sasl_mechanism":"PLAIN","user_id":12345,"deactivated":false,"created":"2020-08-22T08:15:00Z","modified":"2020-08-22T08:15:00Z","description":"","logical_clusters":[{"id":"lkc-xyz34","type":"kafka"}],"account_id":"env-abc12","service_account":true}
+		]}`)
+	}))
+	defer server.Close()
+	defaultURL := ccloudAPIURL
+	ccloudAPIURL = server.URL
+	defer func() { ccloudAPIURL = defaultURL }()
+	meta := &providerMeta{session: &ccloudapi.Session{AuthToken: "token"}}
+
+	data := schema.TestResourceDataRaw(t, dataSourceAPIKeys().Schema, map[string]interface{}{
+		"environment_id": "env-abc12",
+		"cluster_id":     "lkc-abc12",
+	})
+	if diags := dataSourceAPIKeysRead(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("Unable to list API Keys: %v", diags)
+	}
+	if ids := data.Get("ids").([]interface{}); !reflect.DeepEqual(ids, []interface{}{"1", "2"}) {
+		t.Fatalf("Expected the keys of lkc-abc12 but got %v", ids)
+	}
+	if key := data.Get("api_keys.0.key").(string); key != "KEY1" {
+		t.Fatalf("Expected KEY1 but got %s", key)
+	}
+
+	expected := map[string]interface{}{
+		"id":             "2",
+		"key":            "KEY2",
+		"environment_id": "env-abc12",
+		"cluster_ids":    []interface{}{"lkc-abc12"},
+		"owner_id":       "12345",
+		"description":    "orders-service",
+		"created_at":     "2020-08-21T09:30:00Z",
+	}
+	if apiKey := data.Get("api_keys.1"); !reflect.DeepEqual(apiKey, expected) {
+		t.Fatalf("Expected %v but got %v", expected, apiKey)
+	}
+
+	data = schema.TestResourceDataRaw(t, dataSourceAPIKeys().Schema, map[string]interface{}{
+		"environment_id": "env-abc12",
+		"owner_id":       "12345",
+	})
+	if diags := dataSourceAPIKeysRead(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("Unable to list API Keys: %v", diags)
+	}
+	if ids := data.Get("ids").([]interface{}); !reflect.DeepEqual(ids, []interface{}{"2", "3"}) {
+		t.Fatalf("Expected the keys owned by 12345 but got %v", ids)
+	}
+
+	data = schema.TestResourceDataRaw(t, dataSourceAPIKeys().Schema, map[string]interface{}{
+		"environment_id": "env-abc12",
+	})
+	if diags := dataSourceAPIKeysRead(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("Unable to list API Keys: %v", diags)
+	}
+	if keys := data.Get("keys").([]interface{}); len(keys) != 3 {
+		t.Fatalf("Expected all 3 keys of the environment but got %v", keys)
+	}
+
+	data = schema.TestResourceDataRaw(t, dataSourceAPIKeys().Schema, map[string]interface{}{
+		"environment_id": "env-missing",
+	})
+	diags := dataSourceAPIKeysRead(context.Background(), data, meta)
+	if !diags.HasError() {
+		t.Fatal("Listing the keys of a missing environment didn't fail")
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,9 +18,17 @@ func dataSourceCluster() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"cloud_provider": {
 				Type:     schema.TypeString,
@@ -65,37 +75,125 @@ func dataSourceCluster() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"bootstrap_servers": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bootstrap_host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bootstrap_port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"kafka_rest_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func dataSourceClusterRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	session := meta.(*ccloudapi.Session)
-	name := data.Get("name").(string)
+	session := meta.(*providerMeta).session
 	environmentID := data.Get("environment_id").(string)
+	if id, ok := data.GetOk("id"); ok {
+		cluster, err := ccloudapi.ReadCluster(id.(string), environmentID, session)
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(err)
+		}
+		if cluster == nil {
+			return diag.Errorf("No cluster found with id '%s' in "+
+				"environment %s", id, environmentID)
+		}
+		if !clusterMatchesFilters(data, cluster) {
+			return diag.Errorf("Cluster '%s' does not match the "+
+				"given filters", id)
+		}
+		setClusterDataSourceAttributes(data, cluster)
+		return diags
+	}
+	name := data.Get("name").(string)
 	clusters, err := ccloudapi.ListClusters(environmentID, session)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if len(clusters) > 0 {
-		for _, cluster := range clusters {
-			if cluster.Name == name {
-				data.SetId(cluster.ID)
-				data.Set("name", cluster.Name)
-				data.Set("environment_id", cluster.EnvironmentID)
-				data.Set("cloud_provider", cluster.CloudProvider)
-				data.Set("cloud_region", cluster.CloudRegion)
-				data.Set("network_ingress", cluster.NetworkIngress)
-				data.Set("network_egress", cluster.NetworkEgress)
-				data.Set("storage", cluster.Storage)
-				data.Set("durability", cluster.Durability)
-				data.Set("organization_id", cluster.OrganizationID)
-				data.Set("cluster_endpoint", cluster.ClusterEndpoint)
-				data.Set("api_endpoint", cluster.APIEndpoint)
-				break
-			}
+	matches := []*ccloudapi.Cluster{}
+	for _, cluster := range clusters {
+		if cluster.Name == name && clusterMatchesFilters(data, cluster) {
+			matches = append(matches, cluster)
 		}
 	}
-	return diags
+	switch len(matches) {
+	case 0:
+		return diag.Errorf("No cluster found with name '%s' in "+
+			"environment %s matching the given filters", name, environmentID)
+	case 1:
+		setClusterDataSourceAttributes(data, matches[0])
+		return diags
+	default:
+		candidates := []string{}
+		for _, cluster := range matches {
+			candidates = append(candidates, fmt.Sprintf("%s (%s/%s)",
+				cluster.ID, cluster.CloudProvider, cluster.CloudRegion))
+		}
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Ambiguous cluster name",
+				Detail: fmt.Sprintf("Found %d clusters named '%s': %s. "+
+					"Use the id attribute or more filters to pick one "+
+					"of them", len(matches), name,
+					strings.Join(candidates, ", ")),
+			},
+		}
+	}
+}
+
+// clusterMatchesFilters checks the cluster against the optional
+// attributes of the data source that were set in the configuration.
+func clusterMatchesFilters(data *schema.ResourceData, cluster *ccloudapi.Cluster) bool {
+	stringFilters := map[string]string{
+		"cloud_provider":   cluster.CloudProvider,
+		"cloud_region":     cluster.CloudRegion,
+		"durability":       cluster.Durability,
+		"cluster_endpoint": cluster.ClusterEndpoint,
+		"api_endpoint":     cluster.APIEndpoint,
+	}
+	for attribute, value := range stringFilters {
+		if filter, ok := data.GetOk(attribute); ok && filter.(string) != value {
+			return false
+		}
+	}
+	intFilters := map[string]int{
+		"network_ingress": cluster.NetworkIngress,
+		"network_egress":  cluster.NetworkEgress,
+		"storage":         cluster.Storage,
+		"organization_id": cluster.OrganizationID,
+	}
+	for attribute, value := range intFilters {
+		if filter, ok := data.GetOk(attribute); ok && filter.(int) != value {
+			return false
+		}
+	}
+	return true
+}
+
+func setClusterDataSourceAttributes(data *schema.ResourceData, cluster *ccloudapi.Cluster) {
+	data.SetId(cluster.ID)
+	data.Set("name", cluster.Name)
+	data.Set("environment_id", cluster.EnvironmentID)
+	data.Set("cloud_provider", cluster.CloudProvider)
+	data.Set("cloud_region", cluster.CloudRegion)
+	data.Set("network_ingress", cluster.NetworkIngress)
+	data.Set("network_egress", cluster.NetworkEgress)
+	data.Set("storage", cluster.Storage)
+	data.Set("durability", cluster.Durability)
+	data.Set("organization_id", cluster.OrganizationID)
+	data.Set("cluster_endpoint", cluster.ClusterEndpoint)
+	data.Set("api_endpoint", cluster.APIEndpoint)
+	data.Set("kafka_rest_endpoint", kafkaRESTEndpoint(cluster.APIEndpoint))
+	setBootstrapAttributes(data, cluster.ClusterEndpoint)
 }
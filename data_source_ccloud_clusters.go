@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	ccloudapi "github.com/riferrei/ccloud-sdk-go"
+)
+
+func dataSourceClusters() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClustersRead,
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"cloud_provider": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cloud_region": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"durability": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(durabilityOptions, false),
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"clusters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"environment_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cloud_provider": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cloud_region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"network_ingress": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"network_egress": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"storage": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"durability": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"organization_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"cluster_endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"api_endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bootstrap_servers": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kafka_rest_endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceClustersRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	session := meta.(*providerMeta).session
+	environmentIDs := []string{}
+	if environmentID, ok := data.GetOk("environment_id"); ok {
+		environmentIDs = append(environmentIDs, environmentID.(string))
+		data.SetId(environmentID.(string))
+	} else {
+		environments, err := ccloudapi.ListEnvironments(session)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, environment := range environments {
+			environmentIDs = append(environmentIDs, environment.ID)
+		}
+		data.SetId(strconv.Itoa(session.User.OrganizationID))
+	}
+	var nameRegex *regexp.Regexp
+	if value, ok := data.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(value.(string))
+	}
+	filters := map[string]string{
+		"cloud_provider": data.Get("cloud_provider").(string),
+		"cloud_region":   data.Get("cloud_region").(string),
+		"durability":     data.Get("durability").(string),
+	}
+	ids := []string{}
+	matches := []map[string]interface{}{}
+	for _, environmentID := range environmentIDs {
+		clusters, err := ccloudapi.ListClusters(environmentID, session)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, cluster := range clusters {
+			if nameRegex != nil && !nameRegex.MatchString(cluster.Name) {
+				continue
+			}
+			values := map[string]string{
+				"cloud_provider": cluster.CloudProvider,
+				"cloud_region":   cluster.CloudRegion,
+				"durability":     cluster.Durability,
+			}
+			matchesFilters := true
+			for attribute, filter := range filters {
+				if len(filter) > 0 && filter != values[attribute] {
+					matchesFilters = false
+					break
+				}
+			}
+			if !matchesFilters {
+				continue
+			}
+			bootstrapServers, _, _ := splitClusterEndpoint(cluster.ClusterEndpoint)
+			ids = append(ids, cluster.ID)
+			matches = append(matches, map[string]interface{}{
+				"id":                  cluster.ID,
+				"environment_id":      environmentID,
+				"name":                cluster.Name,
+				"cloud_provider":      cluster.CloudProvider,
+				"cloud_region":        cluster.CloudRegion,
+				"network_ingress":     cluster.NetworkIngress,
+				"network_egress":      cluster.NetworkEgress,
+				"storage":             cluster.Storage,
+				"durability":          cluster.Durability,
+				"organization_id":     cluster.OrganizationID,
+				"cluster_endpoint":    cluster.ClusterEndpoint,
+				"api_endpoint":        cluster.APIEndpoint,
+				"bootstrap_servers":   bootstrapServers,
+				"kafka_rest_endpoint": kafkaRESTEndpoint(cluster.APIEndpoint),
+			})
+		}
+	}
+	data.Set("ids", ids)
+	data.Set("clusters", matches)
+	return diags
+}
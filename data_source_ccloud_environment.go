@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,9 +14,17 @@ func dataSourceEnvironment() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceEnvironmentRead,
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"organization_id": {
 				Type:     schema.TypeInt,
@@ -27,21 +37,54 @@ func dataSourceEnvironment() *schema.Resource {
 
 func dataSourceEnvironmentRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	session := meta.(*ccloudapi.Session)
+	session := meta.(*providerMeta).session
+	if id, ok := data.GetOk("id"); ok {
+		environment, err := ccloudapi.ReadEnvironment(id.(string), session)
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(err)
+		}
+		if environment == nil {
+			return diag.Errorf("No environment found with id '%s'", id)
+		}
+		setEnvironmentDataSourceAttributes(data, environment)
+		return diags
+	}
 	name := data.Get("name").(string)
 	environments, err := ccloudapi.ListEnvironments(session)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if len(environments) > 0 {
-		for _, environment := range environments {
-			if environment.Name == name {
-				data.SetId(environment.ID)
-				data.Set("name", environment.Name)
-				data.Set("organization_id", environment.OrganizationID)
-				break
-			}
+	matches := []*ccloudapi.Environment{}
+	for _, environment := range environments {
+		if environment.Name == name {
+			matches = append(matches, environment)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return diag.Errorf("No environment found with name '%s'", name)
+	case 1:
+		setEnvironmentDataSourceAttributes(data, matches[0])
+		return diags
+	default:
+		candidates := []string{}
+		for _, environment := range matches {
+			candidates = append(candidates, environment.ID)
+		}
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Ambiguous environment name",
+				Detail: fmt.Sprintf("Found %d environments named '%s': %s. "+
+					"Use the id attribute to pick one of them", len(matches),
+					name, strings.Join(candidates, ", ")),
+			},
 		}
 	}
-	return diags
+}
+
+func setEnvironmentDataSourceAttributes(data *schema.ResourceData, environment *ccloudapi.Environment) {
+	data.SetId(environment.ID)
+	data.Set("name", environment.Name)
+	data.Set("organization_id", environment.OrganizationID)
 }
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	ccloudapi "github.com/riferrei/ccloud-sdk-go"
+)
+
+func dataSourceEnvironments() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEnvironmentsRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"environments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"organization_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEnvironmentsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	session := meta.(*providerMeta).session
+	name := data.Get("name").(string)
+	var nameRegex *regexp.Regexp
+	if value, ok := data.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(value.(string))
+	}
+	environments, err := ccloudapi.ListEnvironments(session)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := []string{}
+	names := []string{}
+	matches := []map[string]interface{}{}
+	for _, environment := range environments {
+		if len(name) > 0 && environment.Name != name {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(environment.Name) {
+			continue
+		}
+		ids = append(ids, environment.ID)
+		names = append(names, environment.Name)
+		matches = append(matches, map[string]interface{}{
+			"id":              environment.ID,
+			"name":            environment.Name,
+			"organization_id": environment.OrganizationID,
+		})
+	}
+	data.SetId(strconv.Itoa(session.User.OrganizationID))
+	data.Set("ids", ids)
+	data.Set("names", names)
+	data.Set("environments", matches)
+	return diags
+}
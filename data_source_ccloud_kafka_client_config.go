@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ccloudapi "github.com/riferrei/ccloud-sdk-go"
+)
+
+const (
+	clientSecurityProtocol = "SASL_SSL"
+	clientSASLMechanism    = "PLAIN"
+)
+
+func dataSourceKafkaClientConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKafkaClientConfigRead,
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"api_key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"api_secret": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"bootstrap_servers": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"java_properties": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"librdkafka_config": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"spring_boot_yaml": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"environment_variables": {
+				Type:      schema.TypeMap,
+				Computed:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKafkaClientConfigRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	session := meta.(*providerMeta).session
+	environmentID := data.Get("environment_id").(string)
+	clusterID := data.Get("cluster_id").(string)
+	cluster, err := ccloudapi.ReadCluster(clusterID, environmentID, session)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+	if cluster == nil {
+		return diag.Errorf("No cluster found with id '%s' in "+
+			"environment %s", clusterID, environmentID)
+	}
+	bootstrapServers, _, _ := splitClusterEndpoint(cluster.ClusterEndpoint)
+	apiKey := data.Get("api_key").(string)
+	apiSecret := data.Get("api_secret").(string)
+	data.SetId(cluster.ID)
+	data.Set("bootstrap_servers", bootstrapServers)
+	data.Set("java_properties", renderJavaProperties(bootstrapServers, apiKey, apiSecret))
+	data.Set("librdkafka_config", renderLibrdkafkaConfig(bootstrapServers, apiKey, apiSecret))
+	data.Set("spring_boot_yaml", renderSpringBootYAML(bootstrapServers, apiKey, apiSecret))
+	data.Set("environment_variables", renderEnvironmentVariables(bootstrapServers, apiKey, apiSecret))
+	return diags
+}
+
+// saslJAASConfig builds the JAAS configuration used by Java
+// clients, escaping quotes so the credentials can't break it.
+func saslJAASConfig(apiKey, apiSecret string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return fmt.Sprintf("org.apache.kafka.common.security.plain.PlainLoginModule "+
+		"required username=\"%s\" password=\"%s\";",
+		escaper.Replace(apiKey), escaper.Replace(apiSecret))
+}
+
+// escapePropertiesValue escapes what java.util.Properties would
+// otherwise interpret, as backslashes are escape characters there.
+func escapePropertiesValue(value string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, "\n", `\n`,
+		"\r", `\r`, "\t", `\t`, "\f", `\f`)
+	return escaper.Replace(value)
+}
+
+func renderJavaProperties(bootstrapServers, apiKey, apiSecret string) string {
+	return fmt.Sprintf("bootstrap.servers=%s\n"+
+		"security.protocol=%s\n"+
+		"sasl.mechanism=%s\n"+
+		"sasl.jaas.config=%s\n",
+		escapePropertiesValue(bootstrapServers), clientSecurityProtocol,
+		clientSASLMechanism, escapePropertiesValue(saslJAASConfig(apiKey, apiSecret)))
+}
+
+func renderLibrdkafkaConfig(bootstrapServers, apiKey, apiSecret string) string {
+	return fmt.Sprintf("bootstrap.servers=%s\n"+
+		"security.protocol=%s\n"+
+		"sasl.mechanisms=%s\n"+
+		"sasl.username=%s\n"+
+		"sasl.password=%s\n",
+		bootstrapServers, clientSecurityProtocol, clientSASLMechanism,
+		apiKey, apiSecret)
+}
+
+func renderSpringBootYAML(bootstrapServers, apiKey, apiSecret string) string {
+	quote := func(value string) string {
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	return fmt.Sprintf("spring:\n"+
+		"  kafka:\n"+
+		"    bootstrap-servers: %s\n"+
+		"    properties:\n"+
+		"      security.protocol: %s\n"+
+		"      sasl.mechanism: %s\n"+
+		"      sasl.jaas.config: %s\n",
+		quote(bootstrapServers), clientSecurityProtocol, clientSASLMechanism,
+		quote(saslJAASConfig(apiKey, apiSecret)))
+}
+
+func renderEnvironmentVariables(bootstrapServers, apiKey, apiSecret string) map[string]string {
+	return map[string]string{
+		"KAFKA_BOOTSTRAP_SERVERS": bootstrapServers,
+		"KAFKA_SECURITY_PROTOCOL": clientSecurityProtocol,
+		"KAFKA_SASL_MECHANISM":    clientSASLMechanism,
+		"KAFKA_SASL_USERNAME":     apiKey,
+		"KAFKA_SASL_PASSWORD":     apiSecret,
+		"KAFKA_SASL_JAAS_CONFIG":  saslJAASConfig(apiKey, apiSecret),
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+const testBootstrapServers = "pkc-abc12.westus2.azure.confluent.cloud:9092"
+
+var clientConfigCases = []struct {
+	name      string
+	apiKey    string
+	apiSecret string
+}{
+	{"plain", "ABCDEFGHIJKLMNOP", "abcdefghijklmnopqrstuvwxyz0123456789"},
+	{"special", "ABCDEFGHIJKLMNOP", `se"cr\et'1`},
+}
+
+func TestRenderClientConfig(t *testing.T) {
+	for _, c := range clientConfigCases {
+		outputs := map[string]string{
+			"java.properties":   renderJavaProperties(testBootstrapServers, c.apiKey, c.apiSecret),
+			"librdkafka.config": renderLibrdkafkaConfig(testBootstrapServers, c.apiKey, c.apiSecret),
+			"spring_boot.yaml":  renderSpringBootYAML(testBootstrapServers, c.apiKey, c.apiSecret),
+			"environment_variables.env": formatEnvironmentVariables(
+				renderEnvironmentVariables(testBootstrapServers, c.apiKey, c.apiSecret)),
+		}
+		for format, output := range outputs {
+			goldenFile := filepath.Join("testdata", "kafka_client_config",
+				fmt.Sprintf("%s.%s.golden", c.name, format))
+			if *updateGolden {
+				if err := ioutil.WriteFile(goldenFile, []byte(output), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := ioutil.ReadFile(goldenFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output != string(expected) {
+				t.Errorf("Output for %s doesn't match %s:\n%s", format, goldenFile, output)
+			}
+		}
+	}
+}
+
+// The secret has to survive both the escaping of the JAAS config and
+// the one of the file format it ends up in, for Java clients to log in.
+func TestClientConfigKeepsSecret(t *testing.T) {
+	for _, c := range clientConfigCases {
+		properties := renderJavaProperties(testBootstrapServers, c.apiKey, c.apiSecret)
+		jaasConfig := ""
+		for _, line := range strings.Split(properties, "\n") {
+			if strings.HasPrefix(line, "sasl.jaas.config=") {
+				jaasConfig = unescapePropertiesValue(strings.TrimPrefix(line, "sasl.jaas.config="))
+			}
+		}
+		if secret := jaasPassword(jaasConfig); secret != c.apiSecret {
+			t.Errorf("Java properties carry the secret %q instead of %q", secret, c.apiSecret)
+		}
+		springBoot := struct {
+			Spring struct {
+				Kafka struct {
+					Properties map[string]string `yaml:"properties"`
+				} `yaml:"kafka"`
+			} `yaml:"spring"`
+		}{}
+		err := yaml.Unmarshal([]byte(renderSpringBootYAML(testBootstrapServers,
+			c.apiKey, c.apiSecret)), &springBoot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jaasConfig = springBoot.Spring.Kafka.Properties["sasl.jaas.config"]
+		if secret := jaasPassword(jaasConfig); secret != c.apiSecret {
+			t.Errorf("Spring Boot YAML carries the secret %q instead of %q", secret, c.apiSecret)
+		}
+	}
+}
+
+func formatEnvironmentVariables(variables map[string]string) string {
+	names := []string{}
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var builder strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&builder, "%s=%s\n", name, variables[name])
+	}
+	return builder.String()
+}
+
+// unescapePropertiesValue reads a value the way java.util.Properties
+// does, where a backslash drops itself and escapes the next character.
+func unescapePropertiesValue(value string) string {
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			builder.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 't':
+			builder.WriteByte('\t')
+		case 'f':
+			builder.WriteByte('\f')
+		default:
+			builder.WriteByte(value[i])
+		}
+	}
+	return builder.String()
+}
+
+// jaasPassword extracts the password option of a JAAS config,
+// undoing the escaping of quotes and backslashes.
+func jaasPassword(jaasConfig string) string {
+	index := strings.Index(jaasConfig, `password="`)
+	if index < 0 {
+		return ""
+	}
+	var builder strings.Builder
+	value := jaasConfig[index+len(`password="`):]
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i < len(value)-1:
+			i++
+			builder.WriteByte(value[i])
+		case value[i] == '"':
+			return builder.String()
+		default:
+			builder.WriteByte(value[i])
+		}
+	}
+	return ""
+}
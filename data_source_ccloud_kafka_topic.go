@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKafkaTopic() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKafkaTopicRead,
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"credentials": kafkaCredentialsSchema(),
+			"topic_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"partitions_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"replication_factor": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"config": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKafkaTopicRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := data.Get("topic_name").(string)
+	topic, err := client.ReadTopic(ctx, name)
+	if err != nil {
+		if isKafkaRESTNotFound(err) {
+			return diag.Errorf("No topic found with name '%s' in "+
+				"cluster %s", name, client.clusterID)
+		}
+		return diag.FromErr(err)
+	}
+	configs, err := effectiveTopicConfigs(ctx, client, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(kafkaTopicID(client.clusterID, topic.TopicName))
+	data.Set("cluster_id", client.clusterID)
+	data.Set("partitions_count", topic.PartitionsCount)
+	data.Set("replication_factor", topic.ReplicationFactor)
+	data.Set("config", configs)
+	return diags
+}
+
+// effectiveTopicConfigs returns the value of every config of
+// the topic, whether it was set on the topic or inherited.
+func effectiveTopicConfigs(ctx context.Context, client *kafkaRESTClient, name string) (map[string]string, error) {
+	topicConfigs, err := client.ListTopicConfigs(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	configs := map[string]string{}
+	for _, topicConfig := range topicConfigs {
+		if topicConfig.Value != nil {
+			configs[topicConfig.Name] = *topicConfig.Value
+		}
+	}
+	return configs, nil
+}
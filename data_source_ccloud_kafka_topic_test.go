@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceKafkaTopicRead(t *testing.T) {
+	fake := newFakeKafkaREST(t)
+	fake.topics["orders"] = &fakeTopic{partitionsCount: 6,
+		configs: map[string]string{"cleanup.policy": "compact"}}
+	meta := &providerMeta{kafka: fake.kafkaSettings()}
+
+	data := schema.TestResourceDataRaw(t, dataSourceKafkaTopic().Schema, map[string]interface{}{
+		"topic_name": "orders",
+	})
+	if diags := dataSourceKafkaTopicRead(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("Unable to read topic: %v", diags)
+	}
+	if data.Id() != testClusterID+"/orders" || data.Get("cluster_id") != testClusterID {
+		t.Fatalf("Expected topic orders of %s but got %s", testClusterID, data.Id())
+	}
+	if data.Get("partitions_count") != 6 || data.Get("replication_factor") != 3 {
+		t.Fatalf("Expected 6 partitions and 3 replicas but got %v and %v",
+			data.Get("partitions_count"), data.Get("replication_factor"))
+	}
+	// Inherited configs are included along with the ones set on the topic
+	expected := map[string]interface{}{
+		"cleanup.policy": "compact",
+		"retention.ms":   "604800000",
+	}
+	if configs := data.Get("config"); !reflect.DeepEqual(configs, expected) {
+		t.Fatalf("Expected configs %v but got %v", expected, configs)
+	}
+
+	data = schema.TestResourceDataRaw(t, dataSourceKafkaTopic().Schema, map[string]interface{}{
+		"topic_name": "missing",
+	})
+	if diags := dataSourceKafkaTopicRead(context.Background(), data, meta); !diags.HasError() {
+		t.Fatal("Reading a missing topic didn't fail")
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceKafkaTopics() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKafkaTopicsRead,
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"credentials": kafkaCredentialsSchema(),
+			"name_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"include_internal": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"topics": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"topic_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"partitions_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"replication_factor": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"config": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKafkaTopicsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	namePrefix := data.Get("name_prefix").(string)
+	var nameRegex *regexp.Regexp
+	if value, ok := data.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(value.(string))
+	}
+	includeInternal := data.Get("include_internal").(bool)
+	topics, err := client.ListTopics(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	matches := []*kafkaTopic{}
+	for _, topic := range topics {
+		if topic.IsInternal && !includeInternal {
+			continue
+		}
+		if !strings.HasPrefix(topic.TopicName, namePrefix) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(topic.TopicName) {
+			continue
+		}
+		matches = append(matches, topic)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].TopicName < matches[j].TopicName
+	})
+	var mutex sync.Mutex
+	topicConfigs := map[string]map[string]string{}
+	tasks := []func() error{}
+	for _, topic := range matches {
+		name := topic.TopicName
+		tasks = append(tasks, func() error {
+			configs, err := effectiveTopicConfigs(ctx, client, name)
+			if err != nil {
+				return topicError(name, err)
+			}
+			mutex.Lock()
+			topicConfigs[name] = configs
+			mutex.Unlock()
+			return nil
+		})
+	}
+	err = runConcurrently(defaultMaxConcurrency, tasks)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	names := []string{}
+	items := []map[string]interface{}{}
+	for _, topic := range matches {
+		names = append(names, topic.TopicName)
+		items = append(items, map[string]interface{}{
+			"topic_name":         topic.TopicName,
+			"partitions_count":   topic.PartitionsCount,
+			"replication_factor": topic.ReplicationFactor,
+			"config":             topicConfigs[topic.TopicName],
+		})
+	}
+	data.SetId(client.clusterID)
+	data.Set("cluster_id", client.clusterID)
+	data.Set("names", names)
+	data.Set("topics", items)
+	return diags
+}
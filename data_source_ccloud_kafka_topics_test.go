@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceKafkaTopicsRead(t *testing.T) {
+	fake := newFakeKafkaREST(t)
+	for name, partitionsCount := range map[string]int{
+		"orders":             6,
+		"orders-dlq":         1,
+		"payments":           3,
+		"_confluent-metrics": 12,
+	} {
+		fake.topics[name] = &fakeTopic{partitionsCount: partitionsCount,
+			configs: map[string]string{}}
+	}
+	fake.topics["payments"].configs["retention.ms"] = "86400000"
+	meta := &providerMeta{kafka: fake.kafkaSettings()}
+
+	cases := []struct {
+		config map[string]interface{}
+		names  []interface{}
+	}{
+		{map[string]interface{}{}, []interface{}{"orders", "orders-dlq", "payments"}},
+		{map[string]interface{}{"include_internal": true},
+			[]interface{}{"_confluent-metrics", "orders", "orders-dlq", "payments"}},
+		{map[string]interface{}{"name_prefix": "orders"}, []interface{}{"orders", "orders-dlq"}},
+		{map[string]interface{}{"name_regex": "-dlq$"}, []interface{}{"orders-dlq"}},
+		{map[string]interface{}{"name_prefix": "orders", "name_regex": "^pay"}, []interface{}{}},
+		{map[string]interface{}{"name_prefix": "_", "include_internal": true},
+			[]interface{}{"_confluent-metrics"}},
+	}
+	for _, c := range cases {
+		data := schema.TestResourceDataRaw(t, dataSourceKafkaTopics().Schema, c.config)
+		if diags := dataSourceKafkaTopicsRead(context.Background(), data, meta); diags.HasError() {
+			t.Fatalf("Unable to list topics with %v: %v", c.config, diags)
+		}
+		if names := data.Get("names"); !reflect.DeepEqual(names, c.names) {
+			t.Errorf("Expected %v with %v but got %v", c.names, c.config, names)
+		}
+		if topics := data.Get("topics").([]interface{}); len(topics) != len(c.names) {
+			t.Errorf("Expected %d topics with %v but got %d", len(c.names), c.config, len(topics))
+		}
+	}
+
+	data := schema.TestResourceDataRaw(t, dataSourceKafkaTopics().Schema, map[string]interface{}{})
+	if diags := dataSourceKafkaTopicsRead(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("Unable to list topics: %v", diags)
+	}
+	if data.Id() != testClusterID {
+		t.Fatalf("Expected ID %s but got %s", testClusterID, data.Id())
+	}
+	// Inherited configs are included along with the ones set on the topic
+	expected := map[string]interface{}{
+		"topic_name":         "payments",
+		"partitions_count":   3,
+		"replication_factor": 3,
+		"config":             map[string]interface{}{"retention.ms": "86400000"},
+	}
+	if topic := data.Get("topics.2"); !reflect.DeepEqual(topic, expected) {
+		t.Fatalf("Expected %v but got %v", expected, topic)
+	}
+	configs := data.Get("topics.0.config").(map[string]interface{})
+	if retention := configs["retention.ms"]; retention != "604800000" {
+		t.Fatalf("Expected the inherited retention of orders but got %v", retention)
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type configValueType string
+
+const (
+	configBoolean configValueType = "boolean"
+	configInt     configValueType = "int"
+	configLong    configValueType = "long"
+	configDouble  configValueType = "double"
+	configString  configValueType = "string"
+	configList    configValueType = "list"
+)
+
+const maxTopicNameLength = 249
+
+// configDefinition describes a Kafka configuration, how its
+// value is validated and whether Confluent Cloud lets it be set.
+type configDefinition struct {
+	valueType   configValueType
+	minValue    int64
+	maxValue    int64
+	validValues []string
+	readOnly    bool
+}
+
+var (
+	// Cluster-wide settings that Confluent Cloud
+	// allows changing on dedicated clusters.
+	clusterConfigs = map[string]configDefinition{
+		"auto.create.topics.enable":         {valueType: configBoolean},
+		"num.partitions":                    {valueType: configInt, minValue: 1},
+		"log.cleaner.max.compaction.lag.ms": {valueType: configLong, minValue: 21600000},
+		"log.retention.ms":                  {valueType: configLong, minValue: -1},
+		"ssl.cipher.suites":                 {valueType: configList},
+	}
+	// Topic-level settings known to Confluent Cloud. The read-only
+	// ones are valid Kafka configs that Confluent Cloud manages itself.
+	// Limits are the ones of dedicated clusters, as the cluster type
+	// isn't known offline; Basic and Standard clusters may allow less.
+	topicConfigs = map[string]configDefinition{
+		"cleanup.policy":                      {valueType: configList, validValues: []string{"delete", "compact"}},
+		"compression.type":                    {valueType: configString, validValues: []string{"uncompressed", "zstd", "lz4", "snappy", "gzip", "producer"}},
+		"delete.retention.ms":                 {valueType: configLong, minValue: 0},
+		"max.compaction.lag.ms":               {valueType: configLong, minValue: 21600000},
+		"max.message.bytes":                   {valueType: configInt, minValue: 0, maxValue: 20971520},
+		"message.timestamp.difference.max.ms": {valueType: configLong, minValue: 0},
+		"message.timestamp.type":              {valueType: configString, validValues: []string{"CreateTime", "LogAppendTime"}},
+		"min.compaction.lag.ms":               {valueType: configLong, minValue: 0},
+		"min.insync.replicas":                 {valueType: configInt, minValue: 1, maxValue: 2},
+		"retention.bytes":                     {valueType: configLong, minValue: -1},
+		"retention.ms":                        {valueType: configLong, minValue: -1},
+		"segment.bytes":                       {valueType: configInt, minValue: 52428800, maxValue: 1073741824},
+		"segment.ms":                          {valueType: configLong, minValue: 600000},
+		"file.delete.delay.ms":                {valueType: configLong, readOnly: true},
+		"flush.messages":                      {valueType: configLong, readOnly: true},
+		"flush.ms":                            {valueType: configLong, readOnly: true},
+		"index.interval.bytes":                {valueType: configInt, readOnly: true},
+		"message.downconversion.enable":       {valueType: configBoolean, readOnly: true},
+		"message.format.version":              {valueType: configString, readOnly: true},
+		"min.cleanable.dirty.ratio":           {valueType: configDouble, readOnly: true},
+		"preallocate":                         {valueType: configBoolean, readOnly: true},
+		"segment.index.bytes":                 {valueType: configInt, readOnly: true},
+		"segment.jitter.ms":                   {valueType: configLong, readOnly: true},
+		"unclean.leader.election.enable":      {valueType: configBoolean, readOnly: true},
+	}
+	topicNameRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+)
+
+// validateConfigs checks every config against the given catalog.
+// Read-only configs and invalid values are always errors. Configs
+// missing from a strict catalog, which lists all that Confluent Cloud
+// allows, are errors too. Otherwise they are only warned about and
+// left for Confluent Cloud to validate, as the catalog isn't exhaustive.
+func validateConfigs(catalog map[string]configDefinition, kind string, strict bool, configs map[string]interface{}) ([]string, []error) {
+	var errors []error
+	var warns []string
+	names := []string{}
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		definition, ok := catalog[name]
+		if !ok && strict {
+			validNames := []string{}
+			for validName, validDefinition := range catalog {
+				if !validDefinition.readOnly {
+					validNames = append(validNames, validName)
+				}
+			}
+			sort.Strings(validNames)
+			errors = append(errors, fmt.Errorf("Invalid %s config '%s'. "+
+				"Valid configs are: %s", kind, name,
+				strings.Join(validNames, ", ")))
+			continue
+		}
+		if !ok {
+			warns = append(warns, fmt.Sprintf("The %s config '%s' is not "+
+				"known to the provider, so it can't be validated before "+
+				"being sent to Confluent Cloud", kind, name))
+			continue
+		}
+		if definition.readOnly {
+			errors = append(errors, fmt.Errorf("The %s config '%s' is "+
+				"managed by Confluent Cloud and cannot be set", kind, name))
+			continue
+		}
+		value, _ := configs[name].(string)
+		if err := validateConfigValue(name, value, definition); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return warns, errors
+}
+
+// validateConfigValue checks if the value is of the
+// type and within the range expected by the config.
+func validateConfigValue(name, value string, definition configDefinition) error {
+	switch definition.valueType {
+	case configBoolean:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("Invalid value for config '%s'. "+
+				"Value needs to be either true or false", name)
+		}
+	case configInt, configLong:
+		bitSize := 64
+		if definition.valueType == configInt {
+			bitSize = 32
+		}
+		number, err := strconv.ParseInt(value, 10, bitSize)
+		if err != nil {
+			return fmt.Errorf("Invalid value for config '%s'. "+
+				"Value needs to be of type %s", name, definition.valueType)
+		}
+		if number < definition.minValue {
+			return fmt.Errorf("Invalid value for config '%s'. "+
+				"Value needs to be at least %d", name, definition.minValue)
+		}
+		if definition.maxValue > 0 && number > definition.maxValue {
+			return fmt.Errorf("Invalid value for config '%s'. "+
+				"Value needs to be at most %d", name, definition.maxValue)
+		}
+	case configDouble:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("Invalid value for config '%s'. "+
+				"Value needs to be of type %s", name, definition.valueType)
+		}
+	case configString:
+		if len(definition.validValues) > 0 && !containsString(definition.validValues, value) {
+			return fmt.Errorf("Invalid value for config '%s'. Valid values "+
+				"are: %s", name, strings.Join(definition.validValues, ", "))
+		}
+	case configList:
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if len(item) == 0 {
+				return fmt.Errorf("Invalid value for config '%s'. "+
+					"Value needs to be a comma-separated list", name)
+			}
+			if len(definition.validValues) > 0 && !containsString(definition.validValues, item) {
+				return fmt.Errorf("Invalid value for config '%s'. Valid values "+
+					"are: %s", name, strings.Join(definition.validValues, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// validateTopicName applies the same rules as Kafka to topic names,
+// warning about the names whose metrics could collide with others.
+func validateTopicName(name string) ([]string, []error) {
+	var errors []error
+	var warns []string
+	switch {
+	case len(name) == 0:
+		errors = append(errors, fmt.Errorf("Topic name cannot be empty"))
+	case name == "." || name == "..":
+		errors = append(errors, fmt.Errorf("Topic name cannot be '%s'", name))
+	case len(name) > maxTopicNameLength:
+		errors = append(errors, fmt.Errorf("Topic name '%s' is %d characters "+
+			"long, but can have at most %d", name, len(name), maxTopicNameLength))
+	case !topicNameRegex.MatchString(name):
+		errors = append(errors, fmt.Errorf("Topic name '%s' is invalid. Valid "+
+			"characters are letters, digits, '.', '_' and '-'", name))
+	case strings.ContainsAny(name, "._"):
+		warns = append(warns, fmt.Sprintf("Topic '%s' has a period ('.') "+
+			"or underscore ('_'). Due to limitations in metric names, such "+
+			"topics could collide, so use either of them, but not both", name))
+	}
+	return warns, errors
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
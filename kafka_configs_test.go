@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateConfigs(t *testing.T) {
+	cases := []struct {
+		configs map[string]interface{}
+		warns   int
+		errors  int
+	}{
+		{map[string]interface{}{"cleanup.policy": "compact,delete"}, 0, 0},
+		{map[string]interface{}{"max.message.bytes": "10485760"}, 0, 0},
+		{map[string]interface{}{"max.message.bytes": "20971521"}, 0, 1},
+		{map[string]interface{}{"confluent.value.schema.validation": "true"}, 1, 0},
+		{map[string]interface{}{"confluent.key.subject.name.strategy": "io.confluent.kafka.serializers.subject.TopicNameStrategy"}, 1, 0},
+		{map[string]interface{}{"preallocate": "true"}, 0, 1},
+		{map[string]interface{}{"retention.ms": "one week"}, 0, 1},
+		{map[string]interface{}{"cleanup.policy": "archive"}, 0, 1},
+	}
+	for _, c := range cases {
+		warns, errors := validateConfigs(topicConfigs, "topic", false, c.configs)
+		if len(warns) != c.warns || len(errors) != c.errors {
+			t.Errorf("Expected %d warnings and %d errors for %v but got %v and %v",
+				c.warns, c.errors, c.configs, warns, errors)
+		}
+	}
+}
+
+func TestValidateClusterConfigs(t *testing.T) {
+	cases := []struct {
+		configs map[string]interface{}
+		errors  int
+	}{
+		{map[string]interface{}{"auto.create.topics.enable": "false"}, 0},
+		{map[string]interface{}{"num.partitions": "0"}, 1},
+		{map[string]interface{}{"log.dirs": "/tmp/kafka-logs"}, 1},
+		{map[string]interface{}{"log.dirs": "/tmp/kafka-logs", "num.partitions": "6"}, 1},
+	}
+	for _, c := range cases {
+		warns, errors := validateConfigs(clusterConfigs, "cluster", true, c.configs)
+		if len(warns) != 0 || len(errors) != c.errors {
+			t.Errorf("Expected no warnings and %d errors for %v but got %v and %v",
+				c.errors, c.configs, warns, errors)
+		}
+	}
+}
+
+func TestValidateTopicName(t *testing.T) {
+	cases := []struct {
+		name   string
+		warns  int
+		errors int
+	}{
+		{"orders", 0, 0},
+		{"orders-v2", 0, 0},
+		{"orders.v2", 1, 0},
+		{"orders_v2", 1, 0},
+		{"", 0, 1},
+		{"..", 0, 1},
+		{"orders/v2", 0, 1},
+		{strings.Repeat("a", maxTopicNameLength), 0, 0},
+		{strings.Repeat("a", maxTopicNameLength+1), 0, 1},
+	}
+	for _, c := range cases {
+		warns, errors := validateTopicName(c.name)
+		if len(warns) != c.warns || len(errors) != c.errors {
+			t.Errorf("Expected %d warnings and %d errors for '%s' but got %v and %v",
+				c.warns, c.errors, c.name, warns, errors)
+		}
+	}
+}
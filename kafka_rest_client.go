@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ccloudapi "github.com/riferrei/ccloud-sdk-go"
+)
+
+const (
+	kafkaRESTClustersURI    = "/kafka/v3/clusters"
+	kafkaRESTEndpointEnvVar = "CCLOUD_KAFKA_REST_ENDPOINT"
+	kafkaClusterIDEnvVar    = "CCLOUD_KAFKA_CLUSTER_ID"
+	kafkaAPIKeyEnvVar       = "CCLOUD_KAFKA_API_KEY"
+	kafkaAPISecretEnvVar    = "CCLOUD_KAFKA_API_SECRET"
+)
+
+var kafkaRESTHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// kafkaRESTClient talks to the Kafka REST API (v3)
+// exposed by a single Confluent Cloud cluster.
+type kafkaRESTClient struct {
+	endpoint  string
+	clusterID string
+	apiKey    string
+	apiSecret string
+}
+
+// kafkaSettings holds what the kafka block of the provider
+// sets for the single-cluster case: the cluster, its REST
+// endpoint and the API Key used to talk to it.
+type kafkaSettings struct {
+	restEndpoint string
+	clusterID    string
+	apiKey       string
+	apiSecret    string
+}
+
+// kafkaRESTError is returned whenever the Kafka
+// REST API replies with a non-successful status.
+type kafkaRESTError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *kafkaRESTError) Error() string {
+	if len(e.Message) > 0 {
+		return fmt.Sprintf("%d %s: %s", e.StatusCode,
+			http.StatusText(e.StatusCode), e.Message)
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// isKafkaRESTNotFound tells whether the error means
+// that the requested entity does not exist.
+func isKafkaRESTNotFound(err error) bool {
+	restErr, ok := err.(*kafkaRESTError)
+	return ok && restErr.StatusCode == http.StatusNotFound
+}
+
+// kafkaConfig is a single configuration entry as
+// represented by the Kafka REST API.
+type kafkaConfig struct {
+	Name        string  `json:"name"`
+	Value       *string `json:"value,omitempty"`
+	Operation   string  `json:"operation,omitempty"`
+	IsDefault   bool    `json:"is_default,omitempty"`
+	IsReadOnly  bool    `json:"is_read_only,omitempty"`
+	IsSensitive bool    `json:"is_sensitive,omitempty"`
+	Source      string  `json:"source,omitempty"`
+}
+
+type kafkaConfigList struct {
+	Data []*kafkaConfig `json:"data"`
+}
+
+type kafkaTopicList struct {
+	Data []*kafkaTopic `json:"data"`
+}
+
+// kafkaTopic is a topic as represented by the Kafka REST API.
+type kafkaTopic struct {
+	TopicName         string         `json:"topic_name,omitempty"`
+	PartitionsCount   int            `json:"partitions_count,omitempty"`
+	ReplicationFactor int            `json:"replication_factor,omitempty"`
+	IsInternal        bool           `json:"is_internal,omitempty"`
+	Configs           []*kafkaConfig `json:"configs,omitempty"`
+}
+
+func newKafkaRESTClient(endpoint, clusterID, apiKey, apiSecret string) *kafkaRESTClient {
+	return &kafkaRESTClient{
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		clusterID: clusterID,
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+	}
+}
+
+// kafkaCredentialsSchema is the block that resources talking to the
+// Kafka REST API use to override the API Key set in the provider.
+func kafkaCredentialsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"api_key": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"api_secret": {
+					Type:      schema.TypeString,
+					Required:  true,
+					Sensitive: true,
+				},
+			},
+		},
+	}
+}
+
+// kafkaRESTClientFromData creates a Kafka REST client for the cluster
+// of a resource. Whatever the resource doesn't set is taken from the
+// kafka block of the provider, and the REST endpoint of clusters other
+// than the one in that block is looked up in Confluent Cloud.
+func kafkaRESTClientFromData(data *schema.ResourceData, meta interface{}) (*kafkaRESTClient, error) {
+	settings := meta.(*providerMeta).kafka
+	clusterID := data.Get("cluster_id").(string)
+	if len(clusterID) == 0 {
+		clusterID = settings.clusterID
+	}
+	if len(clusterID) == 0 {
+		return nil, fmt.Errorf("No Kafka cluster given. Set cluster_id " +
+			"either on the resource or in the kafka block of the provider " +
+			"(or " + kafkaClusterIDEnvVar + ")")
+	}
+	apiKey, apiSecret := settings.apiKey, settings.apiSecret
+	if credentials := data.Get("credentials").([]interface{}); len(credentials) > 0 && credentials[0] != nil {
+		credential := credentials[0].(map[string]interface{})
+		apiKey = credential["api_key"].(string)
+		apiSecret = credential["api_secret"].(string)
+	}
+	if len(apiKey) == 0 || len(apiSecret) == 0 {
+		return nil, fmt.Errorf("No API Key given for Kafka cluster %s. "+
+			"Set either a credentials block on the resource or the kafka "+
+			"block of the provider (or %s and %s)", clusterID,
+			kafkaAPIKeyEnvVar, kafkaAPISecretEnvVar)
+	}
+	if len(settings.restEndpoint) > 0 &&
+		(len(settings.clusterID) == 0 || settings.clusterID == clusterID) {
+		return newKafkaRESTClient(settings.restEndpoint, clusterID,
+			apiKey, apiSecret), nil
+	}
+	environmentID := data.Get("environment_id").(string)
+	if len(environmentID) == 0 {
+		return nil, fmt.Errorf("Unable to find the REST endpoint of "+
+			"Kafka cluster %s. Set either environment_id on the resource, "+
+			"so it can be looked up, or rest_endpoint in the kafka block "+
+			"of the provider (or %s)", clusterID, kafkaRESTEndpointEnvVar)
+	}
+	session := meta.(*providerMeta).session
+	cluster, err := ccloudapi.ReadCluster(clusterID, environmentID, session)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	if cluster == nil {
+		return nil, fmt.Errorf("Cluster %s not found in environment %s",
+			clusterID, environmentID)
+	}
+	return newKafkaRESTClient(kafkaRESTEndpoint(cluster.APIEndpoint), clusterID, apiKey, apiSecret), nil
+}
+
+// ListBrokerConfigs returns the cluster-wide broker configs
+func (c *kafkaRESTClient) ListBrokerConfigs(ctx context.Context) ([]*kafkaConfig, error) {
+	configs := new(kafkaConfigList)
+	err := c.doRequest(ctx, "GET", c.clusterURI("/broker-configs"), nil, configs)
+	if err != nil {
+		return nil, err
+	}
+	return configs.Data, nil
+}
+
+// AlterBrokerConfigs sets and resets cluster-wide broker configs
+// in one batch. Configs listed in reset go back to their defaults.
+func (c *kafkaRESTClient) AlterBrokerConfigs(ctx context.Context, set map[string]string, reset []string) error {
+	request := &kafkaConfigList{Data: alterConfigEntries(set, reset)}
+	return c.doRequest(ctx, "POST", c.clusterURI("/broker-configs:alter"), request, nil)
+}
+
+// CreateTopic creates a topic with the given configs
+func (c *kafkaRESTClient) CreateTopic(ctx context.Context, name string, partitionsCount int, configs map[string]string) (*kafkaTopic, error) {
+	request := &kafkaTopic{
+		TopicName:       name,
+		PartitionsCount: partitionsCount,
+		Configs:         alterConfigEntries(configs, nil),
+	}
+	createdTopic := new(kafkaTopic)
+	err := c.doRequest(ctx, "POST", c.clusterURI("/topics"), request, createdTopic)
+	if err != nil {
+		return nil, err
+	}
+	return createdTopic, nil
+}
+
+// ListTopics lists all topics of the cluster
+func (c *kafkaRESTClient) ListTopics(ctx context.Context) ([]*kafkaTopic, error) {
+	topics := new(kafkaTopicList)
+	err := c.doRequest(ctx, "GET", c.clusterURI("/topics"), nil, topics)
+	if err != nil {
+		return nil, err
+	}
+	return topics.Data, nil
+}
+
+// ReadTopic reads an existing topic
+func (c *kafkaRESTClient) ReadTopic(ctx context.Context, name string) (*kafkaTopic, error) {
+	topic := new(kafkaTopic)
+	err := c.doRequest(ctx, "GET", c.topicURI(name, ""), nil, topic)
+	if err != nil {
+		return nil, err
+	}
+	return topic, nil
+}
+
+// UpdatePartitionsCount increases the number of partitions of a topic
+func (c *kafkaRESTClient) UpdatePartitionsCount(ctx context.Context, name string, partitionsCount int) error {
+	request := &kafkaTopic{PartitionsCount: partitionsCount}
+	return c.doRequest(ctx, "PATCH", c.topicURI(name, ""), request, nil)
+}
+
+// DeleteTopic deletes an existing topic
+func (c *kafkaRESTClient) DeleteTopic(ctx context.Context, name string) error {
+	return c.doRequest(ctx, "DELETE", c.topicURI(name, ""), nil, nil)
+}
+
+// ListTopicConfigs returns all configs of a topic, including defaults
+func (c *kafkaRESTClient) ListTopicConfigs(ctx context.Context, name string) ([]*kafkaConfig, error) {
+	configs := new(kafkaConfigList)
+	err := c.doRequest(ctx, "GET", c.topicURI(name, "/configs"), nil, configs)
+	if err != nil {
+		return nil, err
+	}
+	return configs.Data, nil
+}
+
+// AlterTopicConfigs sets and resets configs of a topic in one batch
+func (c *kafkaRESTClient) AlterTopicConfigs(ctx context.Context, name string, set map[string]string, reset []string) error {
+	request := &kafkaConfigList{Data: alterConfigEntries(set, reset)}
+	return c.doRequest(ctx, "POST", c.topicURI(name, "/configs:alter"), request, nil)
+}
+
+// alterConfigEntries builds the payload expected by
+// the ':alter' endpoints of the Kafka REST API.
+func alterConfigEntries(set map[string]string, reset []string) []*kafkaConfig {
+	entries := []*kafkaConfig{}
+	for name, value := range set {
+		value := value
+		entries = append(entries, &kafkaConfig{Name: name, Value: &value})
+	}
+	for _, name := range reset {
+		entries = append(entries, &kafkaConfig{Name: name, Operation: "DELETE"})
+	}
+	return entries
+}
+
+func (c *kafkaRESTClient) clusterURI(path string) string {
+	return kafkaRESTClustersURI + "/" + url.PathEscape(c.clusterID) + path
+}
+
+func (c *kafkaRESTClient) topicURI(name, path string) string {
+	return c.clusterURI("/topics/" + url.PathEscape(name) + path)
+}
+
+// generic function to handle HTTP requests against the Kafka REST API
+func (c *kafkaRESTClient) doRequest(ctx context.Context, method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(payloadBytes)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+uri, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(c.apiKey, c.apiSecret)
+	resp, err := kafkaRESTHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		restErr := &kafkaRESTError{StatusCode: resp.StatusCode}
+		errorResp := struct {
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(respBytes, &errorResp) == nil {
+			restErr.Message = errorResp.Message
+		}
+		return restErr
+	}
+	if result == nil || len(respBytes) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBytes, result)
+}
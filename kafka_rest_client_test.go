@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testClusterID = "lkc-abc12"
+	testAPIKey    = "ABCDEFGHIJKLMNOP"
+	testAPISecret = "secret"
+)
+
+// fakeKafkaREST stands in for the topic endpoints of the
+// Kafka REST API (v3) of a single cluster, keeping topics in memory.
+type fakeKafkaREST struct {
+	*httptest.Server
+	mutex  sync.Mutex
+	topics map[string]*fakeTopic
+}
+
+type fakeTopic struct {
+	partitionsCount int
+	configs         map[string]string
+}
+
+func newFakeKafkaREST(t *testing.T) *fakeKafkaREST {
+	fake := &fakeKafkaREST{topics: map[string]*fakeTopic{}}
+	fake.Server = httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(fake.Close)
+	return fake
+}
+
+// kafkaSettings points the kafka block of the provider at the fake
+func (f *fakeKafkaREST) kafkaSettings() *kafkaSettings {
+	return expandKafkaSettings([]interface{}{
+		map[string]interface{}{
+			"rest_endpoint": f.URL,
+			"cluster_id":    testClusterID,
+			"api_key":       testAPIKey,
+			"api_secret":    testAPISecret,
+		},
+	})
+}
+
+func (f *fakeKafkaREST) topic(name string) *fakeTopic {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.topics[name]
+}
+
+func (f *fakeKafkaREST) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if apiKey, apiSecret, ok := r.BasicAuth(); !ok || apiKey != testAPIKey || apiSecret != testAPISecret {
+		writeKafkaRESTError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	prefix := kafkaRESTClustersURI + "/" + testClusterID + "/topics"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeKafkaRESTError(w, http.StatusNotFound, "Cluster not found")
+		return
+	}
+	path := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if len(path) == 0 {
+		switch r.Method {
+		case "GET":
+			f.listTopics(w)
+		case "POST":
+			f.createTopic(w, r)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+	name, action := path, ""
+	if index := strings.Index(path, "/"); index >= 0 {
+		name, action = path[:index], path[index:]
+	}
+	topic, ok := f.topics[name]
+	if !ok {
+		writeKafkaRESTError(w, http.StatusNotFound, "This server does not host this topic-partition.")
+		return
+	}
+	switch {
+	case action == "" && r.Method == "GET":
+		writeKafkaRESTJSON(w, http.StatusOK, topicJSON(name, topic))
+	case action == "" && r.Method == "PATCH":
+		request := new(kafkaTopic)
+		json.NewDecoder(r.Body).Decode(request)
+		if request.PartitionsCount < topic.partitionsCount {
+			writeKafkaRESTError(w, http.StatusBadRequest, "Topic currently has "+
+				"more partitions than requested")
+			return
+		}
+		topic.partitionsCount = request.PartitionsCount
+		writeKafkaRESTJSON(w, http.StatusOK, topicJSON(name, topic))
+	case action == "" && r.Method == "DELETE":
+		delete(f.topics, name)
+		w.WriteHeader(http.StatusNoContent)
+	case action == "/configs" && r.Method == "GET":
+		writeKafkaRESTJSON(w, http.StatusOK, &kafkaConfigList{Data: topicConfigsJSON(topic)})
+	case action == "/configs:alter" && r.Method == "POST":
+		request := new(kafkaConfigList)
+		json.NewDecoder(r.Body).Decode(request)
+		alterFakeConfigs(topic.configs, request.Data)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeKafkaREST) listTopics(w http.ResponseWriter) {
+	topics := &kafkaTopicList{Data: []*kafkaTopic{}}
+	for name, topic := range f.topics {
+		topics.Data = append(topics.Data, topicJSON(name, topic))
+	}
+	sort.Slice(topics.Data, func(i, j int) bool {
+		return topics.Data[i].TopicName < topics.Data[j].TopicName
+	})
+	writeKafkaRESTJSON(w, http.StatusOK, topics)
+}
+
+func (f *fakeKafkaREST) createTopic(w http.ResponseWriter, r *http.Request) {
+	request := new(kafkaTopic)
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeKafkaRESTError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, ok := f.topics[request.TopicName]; ok {
+		writeKafkaRESTError(w, http.StatusBadRequest,
+			fmt.Sprintf("Topic '%s' already exists.", request.TopicName))
+		return
+	}
+	topic := &fakeTopic{partitionsCount: request.PartitionsCount, configs: map[string]string{}}
+	alterFakeConfigs(topic.configs, request.Configs)
+	f.topics[request.TopicName] = topic
+	writeKafkaRESTJSON(w, http.StatusCreated, topicJSON(request.TopicName, topic))
+}
+
+func alterFakeConfigs(configs map[string]string, entries []*kafkaConfig) {
+	for _, entry := range entries {
+		if entry.Operation == "DELETE" {
+			delete(configs, entry.Name)
+		} else if entry.Value != nil {
+			configs[entry.Name] = *entry.Value
+		}
+	}
+}
+
+func topicJSON(name string, topic *fakeTopic) *kafkaTopic {
+	return &kafkaTopic{
+		TopicName:         name,
+		PartitionsCount:   topic.partitionsCount,
+		ReplicationFactor: 3,
+		IsInternal:        strings.HasPrefix(name, "_"),
+	}
+}
+
+// topicConfigsJSON lists the configs of a topic along
+// with a default one, as the real API returns all of them.
+func topicConfigsJSON(topic *fakeTopic) []*kafkaConfig {
+	defaultValue := "604800000"
+	configs := []*kafkaConfig{}
+	if _, ok := topic.configs["retention.ms"]; !ok {
+		configs = append(configs, &kafkaConfig{Name: "retention.ms",
+			Value: &defaultValue, IsDefault: true, Source: "DEFAULT_CONFIG"})
+	}
+	for name, value := range topic.configs {
+		value := value
+		configs = append(configs, &kafkaConfig{Name: name, Value: &value,
+			Source: topicConfigSource})
+	}
+	return configs
+}
+
+func writeKafkaRESTJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeKafkaRESTError(w http.ResponseWriter, statusCode int, message string) {
+	writeKafkaRESTJSON(w, statusCode, map[string]interface{}{
+		"error_code": statusCode,
+		"message":    message,
+	})
+}
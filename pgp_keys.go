@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+	// Keys that don't state their preferred hashes
+	// fall back to RIPEMD160, as per RFC 4880.
+	_ "golang.org/x/crypto/ripemd160"
+)
+
+const (
+	keybasePrefix     = "keybase:"
+	pgpKeyringEnvVar  = "CCLOUD_PGP_KEYRING"
+	defaultPGPKeyring = ".gnupg/pubring.gpg"
+)
+
+// encryptWithPGPKey encrypts the plaintext with the given PGP key,
+// returning the base64-encoded message and the key fingerprint.
+func encryptWithPGPKey(entity *openpgp.Entity, plaintext string) (string, string, error) {
+	buffer := new(bytes.Buffer)
+	writer, err := openpgp.Encrypt(buffer, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("Unable to encrypt with PGP key: %v", err)
+	}
+	if _, err := writer.Write([]byte(plaintext)); err != nil {
+		return "", "", fmt.Errorf("Unable to encrypt with PGP key: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", "", fmt.Errorf("Unable to encrypt with PGP key: %v", err)
+	}
+	encrypted := base64.StdEncoding.EncodeToString(buffer.Bytes())
+	fingerprint := hex.EncodeToString(entity.PrimaryKey.Fingerprint[:])
+	return encrypted, fingerprint, nil
+}
+
+// resolvePGPKey reads a PGP public key, which is either base64-encoded
+// or a keybase:<name> reference looked up in the local keyring file.
+func resolvePGPKey(pgpKey string) (*openpgp.Entity, error) {
+	if strings.HasPrefix(pgpKey, keybasePrefix) {
+		return lookupKeyring(strings.TrimPrefix(pgpKey, keybasePrefix))
+	}
+	keyBytes, err := base64.StdEncoding.DecodeString(pgpKey)
+	if err != nil {
+		return nil, fmt.Errorf("PGP key is not valid base64: %v", err)
+	}
+	entities, err := openpgp.ReadKeyRing(bytes.NewReader(keyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read PGP key: %v", err)
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("Expected a single PGP key but found %d",
+			len(entities))
+	}
+	return entities[0], nil
+}
+
+// lookupKeyring finds the key whose identity has the given
+// name or email in the keyring file pointed by CCLOUD_PGP_KEYRING,
+// defaulting to ~/.gnupg/pubring.gpg.
+func lookupKeyring(name string) (*openpgp.Entity, error) {
+	keyringPath := os.Getenv(pgpKeyringEnvVar)
+	if len(keyringPath) == 0 {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		keyringPath = filepath.Join(homeDir, defaultPGPKeyring)
+	}
+	keyringFile, err := os.Open(keyringPath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open PGP keyring: %v", err)
+	}
+	defer keyringFile.Close()
+	entities, err := openpgp.ReadKeyRing(keyringFile)
+	if err != nil {
+		keyringFile.Seek(0, 0)
+		entities, err = openpgp.ReadArmoredKeyRing(keyringFile)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read PGP keyring %s: %v",
+				keyringPath, err)
+		}
+	}
+	for _, entity := range entities {
+		for identityName, identity := range entity.Identities {
+			if identityName == name || identity.UserId.Name == name ||
+				identity.UserId.Email == name {
+				return entity, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("No PGP key for '%s' found in keyring %s",
+		name, keyringPath)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func newTestPGPEntity(t *testing.T, name, email string) *openpgp.Entity {
+	t.Helper()
+	entity, err := openpgp.NewEntity(name, "", email, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entity
+}
+
+// publicKeyring serializes the public keys of the entities,
+// as found in keyring files or given as pgp_key.
+func publicKeyring(t *testing.T, entities ...*openpgp.Entity) []byte {
+	t.Helper()
+	buffer := new(bytes.Buffer)
+	for _, entity := range entities {
+		if err := entity.Serialize(buffer); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buffer.Bytes()
+}
+
+// decryptWithPGPKey decrypts what encryptWithPGPKey returns,
+// the same way gpg --decrypt does on the base64-decoded output.
+func decryptWithPGPKey(t *testing.T, entity *openpgp.Entity, encrypted string) string {
+	t.Helper()
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatalf("Encrypted secret is not valid base64: %v", err)
+	}
+	message, err := openpgp.ReadMessage(bytes.NewReader(ciphertext),
+		openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatalf("Unable to decrypt secret: %v", err)
+	}
+	plaintext, err := ioutil.ReadAll(message.UnverifiedBody)
+	if err != nil {
+		t.Fatalf("Unable to decrypt secret: %v", err)
+	}
+	return string(plaintext)
+}
+
+// setPGPKeyring points CCLOUD_PGP_KEYRING at the
+// given keyring file until the test is over.
+func setPGPKeyring(t *testing.T, path string) {
+	defaultPath, ok := os.LookupEnv(pgpKeyringEnvVar)
+	os.Setenv(pgpKeyringEnvVar, path)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(pgpKeyringEnvVar, defaultPath)
+		} else {
+			os.Unsetenv(pgpKeyringEnvVar)
+		}
+	})
+}
+
+func TestEncryptWithPGPKey(t *testing.T) {
+	entity := newTestPGPEntity(t, "Ops", "ops@example.com")
+	pgpKey := base64.StdEncoding.EncodeToString(publicKeyring(t, entity))
+	publicEntity, err := resolvePGPKey(pgpKey)
+	if err != nil {
+		t.Fatalf("Unable to read PGP key: %v", err)
+	}
+	if publicEntity.PrivateKey != nil {
+		t.Fatal("Public key was read with a private key")
+	}
+	encrypted, fingerprint, err := encryptWithPGPKey(publicEntity, "api-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := hex.EncodeToString(entity.PrimaryKey.Fingerprint[:]); fingerprint != expected {
+		t.Fatalf("Expected fingerprint %s but got %s", expected, fingerprint)
+	}
+	if plaintext := decryptWithPGPKey(t, entity, encrypted); plaintext != "api-secret" {
+		t.Fatalf("Expected api-secret but decrypted %s", plaintext)
+	}
+	if _, err := resolvePGPKey("not base64"); err == nil {
+		t.Fatal("Invalid PGP key was accepted")
+	}
+	twoKeys := publicKeyring(t, entity, newTestPGPEntity(t, "Dev", "dev@example.com"))
+	if _, err := resolvePGPKey(base64.StdEncoding.EncodeToString(twoKeys)); err == nil {
+		t.Fatal("PGP key holding two keys was accepted")
+	}
+}
+
+func TestResolvePGPKeyFromKeyring(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ccloud-pgp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ops := newTestPGPEntity(t, "Ops", "ops@example.com")
+	dev := newTestPGPEntity(t, "Dev", "dev@example.com")
+	keyring := publicKeyring(t, dev, ops)
+
+	binaryPath := filepath.Join(dir, "pubring.gpg")
+	if err := ioutil.WriteFile(binaryPath, keyring, 0600); err != nil {
+		t.Fatal(err)
+	}
+	armored := new(bytes.Buffer)
+	writer, err := armor.Encode(armored, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer.Write(keyring)
+	writer.Close()
+	armoredPath := filepath.Join(dir, "pubring.asc")
+	if err := ioutil.WriteFile(armoredPath, armored.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{binaryPath, armoredPath} {
+		setPGPKeyring(t, path)
+		for _, name := range []string{"ops@example.com", "Ops", "Ops <ops@example.com>"} {
+			entity, err := resolvePGPKey(keybasePrefix + name)
+			if err != nil {
+				t.Fatalf("Unable to find '%s' in %s: %v", name, path, err)
+			}
+			if entity.PrimaryKey.KeyId != ops.PrimaryKey.KeyId {
+				t.Fatalf("Found the key of %v for '%s' in %s",
+					entity.Identities, name, path)
+			}
+			encrypted, _, err := encryptWithPGPKey(entity, "api-secret")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if plaintext := decryptWithPGPKey(t, ops, encrypted); plaintext != "api-secret" {
+				t.Fatalf("Expected api-secret but decrypted %s", plaintext)
+			}
+		}
+		if _, err := resolvePGPKey(keybasePrefix + "missing@example.com"); err == nil {
+			t.Fatalf("Missing key was found in %s", path)
+		}
+	}
+
+	setPGPKeyring(t, filepath.Join(dir, "missing.gpg"))
+	if _, err := resolvePGPKey(keybasePrefix + "ops@example.com"); err == nil {
+		t.Fatal("Key was found in a missing keyring")
+	}
+}
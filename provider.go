@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"os"
+	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -30,15 +32,54 @@ func Provider() *schema.Provider {
 					return nil
 				},
 			},
+			"kafka": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rest_endpoint": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc(kafkaRESTEndpointEnvVar, ""),
+						},
+						"cluster_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc(kafkaClusterIDEnvVar, ""),
+						},
+						"api_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc(kafkaAPIKeyEnvVar, ""),
+						},
+						"api_secret": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							DefaultFunc: schema.EnvDefaultFunc(kafkaAPISecretEnvVar, ""),
+						},
+					},
+				},
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"ccloud_environment": dataSourceEnvironment(),
-			"ccloud_cluster":     dataSourceCluster(),
+			"ccloud_environment":         dataSourceEnvironment(),
+			"ccloud_environments":        dataSourceEnvironments(),
+			"ccloud_cluster":             dataSourceCluster(),
+			"ccloud_clusters":            dataSourceClusters(),
+			"ccloud_kafka_client_config": dataSourceKafkaClientConfig(),
+			"ccloud_kafka_topic":         dataSourceKafkaTopic(),
+			"ccloud_kafka_topics":        dataSourceKafkaTopics(),
+			"ccloud_apikeys":             dataSourceAPIKeys(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"ccloud_environment": resourceEnvironment(),
-			"ccloud_cluster":     resourceCluster(),
-			"ccloud_apikey":      resourceAPIKey(),
+			"ccloud_environment":    resourceEnvironment(),
+			"ccloud_cluster":        resourceCluster(),
+			"ccloud_apikey":         resourceAPIKey(),
+			"ccloud_cluster_config": resourceClusterConfig(),
+			"ccloud_kafka_topic":    resourceKafkaTopic(),
+			"ccloud_kafka_topics":   resourceKafkaTopics(),
 		},
 	}
 
@@ -55,9 +96,45 @@ func Provider() *schema.Provider {
 			})
 			return nil, diags
 		}
-		return session, nil
+		return &providerMeta{
+			session: session,
+			kafka:   expandKafkaSettings(d.Get("kafka").([]interface{})),
+		}, nil
 	}
 
 	return provider
 
 }
+
+// providerMeta is what resources and data sources
+// receive as meta once the provider is configured.
+type providerMeta struct {
+	session *ccloudapi.Session
+	kafka   *kafkaSettings
+}
+
+// expandKafkaSettings reads the kafka block of the provider,
+// falling back to environment variables when it is absent.
+func expandKafkaSettings(kafkaBlock []interface{}) *kafkaSettings {
+	if len(kafkaBlock) == 0 || kafkaBlock[0] == nil {
+		return &kafkaSettings{
+			restEndpoint: os.Getenv(kafkaRESTEndpointEnvVar),
+			clusterID:    os.Getenv(kafkaClusterIDEnvVar),
+			apiKey:       os.Getenv(kafkaAPIKeyEnvVar),
+			apiSecret:    os.Getenv(kafkaAPISecretEnvVar),
+		}
+	}
+	settings := kafkaBlock[0].(map[string]interface{})
+	return &kafkaSettings{
+		restEndpoint: settings["rest_endpoint"].(string),
+		clusterID:    settings["cluster_id"].(string),
+		apiKey:       settings["api_key"].(string),
+		apiSecret:    settings["api_secret"].(string),
+	}
+}
+
+// isNotFound tells whether an error returned by the
+// Confluent Cloud client is due to a 404 response.
+func isNotFound(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "404")
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// loadStateFixture reads a state recorded by an earlier
+// version of the provider from testdata/state.
+func loadStateFixture(t *testing.T, name string) map[string]interface{} {
+	t.Helper()
+	content, err := ioutil.ReadFile(filepath.Join("testdata", "state", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawState := map[string]interface{}{}
+	if err := json.Unmarshal(content, &rawState); err != nil {
+		t.Fatal(err)
+	}
+	return rawState
+}
+
+// planAfterUpgrade runs the v0 state through the upgrader of the
+// resource, and returns what Terraform would plan for the config.
+func planAfterUpgrade(t *testing.T, resource *schema.Resource,
+	rawState map[string]interface{}, config map[string]interface{}) *terraform.InstanceDiff {
+	t.Helper()
+	upgradedState, err := resource.StateUpgraders[0].Upgrade(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("Unable to upgrade state: %v", err)
+	}
+	stateJSON, err := json.Marshal(upgradedState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateValue, err := ctyjson.Unmarshal(stateJSON, resource.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("Upgraded state does not match the current schema: %v", err)
+	}
+	instanceState, err := resource.ShimInstanceStateFromValue(stateValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err := resource.Diff(context.Background(), instanceState,
+		terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("Unable to plan: %v", err)
+	}
+	return diff
+}
@@ -2,11 +2,30 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	ccloudapi "github.com/riferrei/ccloud-sdk-go"
+	"golang.org/x/crypto/openpgp"
+)
+
+var (
+	secretOutputFormats = []string{"json", "properties"}
+	numericIDRegex      = regexp.MustCompile(`^[0-9]+$`)
 )
 
 func resourceAPIKey() *schema.Resource {
@@ -15,6 +34,15 @@ func resourceAPIKey() *schema.Resource {
 		ReadContext:   apiKeyRead,
 		UpdateContext: apiKeyUpdate,
 		DeleteContext: apiKeyDelete,
+		CustomizeDiff: apiKeyCustomizeDiff,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceAPIKeyV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: apiKeyStateUpgradeV0,
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"environment_id": {
 				Type:     schema.TypeString,
@@ -26,6 +54,17 @@ func resourceAPIKey() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"owner_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(numericIDRegex, "must be the numeric ID of a user or service account"),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"key": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -37,22 +76,128 @@ func resourceAPIKey() *schema.Resource {
 				Computed:  true,
 				Sensitive: true,
 			},
+			"pgp_key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"secret_output_path"},
+				ValidateFunc: func(v interface{}, k string) (wrs []string, ers []error) {
+					var errors []error
+					var warns []string
+					value, _ := v.(string)
+					if strings.HasPrefix(value, keybasePrefix) {
+						return warns, errors
+					}
+					if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+						errors = append(errors, fmt.Errorf("Invalid value for "+
+							"PGP key. Value needs to be either a base64-encoded "+
+							"public key or %s<name>", keybasePrefix))
+						return warns, errors
+					}
+					return warns, errors
+				},
+			},
+			"encrypted_secret": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"secret_output_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"secret_output_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      secretOutputFormats[0],
+				ValidateFunc: validation.StringInSlice(secretOutputFormats, false),
+			},
+			"secret_checksum": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rotation_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"keepers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expires_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func apiKeyCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	session := meta.(*ccloudapi.Session)
+	session := meta.(*providerMeta).session
 	environmentID := data.Get("environment_id").(string)
 	clusterID := data.Get("cluster_id").(string)
-	createdAPIKey, err := ccloudapi.CreateAPIKey(environmentID, clusterID, session)
+	// The secret is only returned once, so anything needed to
+	// handle it has to be in place before the key is created.
+	var pgpEntity *openpgp.Entity
+	if pgpKey, ok := data.GetOk("pgp_key"); ok {
+		entity, err := resolvePGPKey(pgpKey.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		pgpEntity = entity
+	}
+	if outputPath, ok := data.GetOk("secret_output_path"); ok {
+		if err := checkSecretPath(outputPath.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	ownerID, _ := strconv.Atoi(data.Get("owner_id").(string))
+	createdAPIKey, err := createAPIKey(ctx, session, environmentID, clusterID,
+		ownerID, data.Get("description").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	data.SetId(strconv.Itoa(createdAPIKey.ID))
 	data.Set("key", createdAPIKey.Key)
-	data.Set("secret", createdAPIKey.Secret)
+	if createdAPIKey.OwnerID != 0 {
+		data.Set("owner_id", strconv.Itoa(createdAPIKey.OwnerID))
+	}
+	if outputPath, ok := data.GetOk("secret_output_path"); ok {
+		checksum, err := writeSecretFile(outputPath.(string),
+			data.Get("secret_output_format").(string), &ccloudapi.APIKey{
+				ID: createdAPIKey.ID, Key: createdAPIKey.Key, Secret: createdAPIKey.Secret})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data.Set("secret_checksum", checksum)
+	} else if pgpEntity != nil {
+		encryptedSecret, fingerprint, err := encryptWithPGPKey(pgpEntity, createdAPIKey.Secret)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		data.Set("encrypted_secret", encryptedSecret)
+		data.Set("key_fingerprint", fingerprint)
+	} else {
+		data.Set("secret", createdAPIKey.Secret)
+	}
+	createdAt := time.Now().UTC()
+	data.Set("created_at", createdAt.Format(time.RFC3339))
+	data.Set("expires_at", apiKeyExpiration(createdAt, data.Get("rotation_days").(int)))
 	return diags
 }
 
@@ -61,32 +206,252 @@ func apiKeyRead(ctx context.Context, data *schema.ResourceData, meta interface{}
 	key := data.Get("key").(string)
 	environmentID := data.Get("environment_id").(string)
 	clusterID := data.Get("cluster_id").(string)
-	session := meta.(*ccloudapi.Session)
-	apiKey, err := ccloudapi.ReadAPIKey(environmentID, clusterID, key, session)
-	if err != nil {
+	session := meta.(*providerMeta).session
+	apiKey, err := readAPIKey(ctx, session, environmentID, clusterID, key)
+	if err != nil && !isNotFound(err) {
 		return diag.FromErr(err)
 	}
+	// Keys can only be looked up by their value within a cluster,
+	// while deleting goes by ID. A key that was re-scoped to another
+	// cluster is no longer found, and a value that now belongs to a key
+	// with another ID means the managed key was replaced. Either way the
+	// key being managed is gone from the cluster and must be recreated.
 	if apiKey == nil {
+		log.Printf("[WARN] API Key %s (%s) was deleted or re-scoped to "+
+			"another cluster, as it is no longer found in cluster %s. "+
+			"Removing from state", data.Id(), key, clusterID)
+		data.SetId("")
+		return diags
+	}
+	if apiKey.ID != 0 && strconv.Itoa(apiKey.ID) != data.Id() {
+		log.Printf("[WARN] API Key %s (%s) was reassigned, as its value "+
+			"now belongs to API Key %d in cluster %s. Removing from state",
+			data.Id(), key, apiKey.ID, clusterID)
 		data.SetId("")
 		return diags
 	}
 	data.Set("key", apiKey.Key)
+	data.Set("description", apiKey.Description)
+	if apiKey.OwnerID != 0 {
+		data.Set("owner_id", strconv.Itoa(apiKey.OwnerID))
+	}
+	if outputPath, ok := data.GetOk("secret_output_path"); ok {
+		diags = append(diags, checkSecretFile(outputPath.(string),
+			data.Get("secret_checksum").(string))...)
+	}
 	return diags
 }
 
 func apiKeyUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if data.HasChange("description") {
+		session := meta.(*providerMeta).session
+		id, _ := strconv.Atoi(data.Id())
+		err := updateAPIKey(ctx, session, data.Get("environment_id").(string),
+			data.Get("cluster_id").(string), id, data.Get("description").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if data.HasChange("rotation_days") {
+		// Keys created before rotation was supported have no creation
+		// time, so the rotation period starts when it is first set.
+		createdAt, err := time.Parse(time.RFC3339, data.Get("created_at").(string))
+		if err != nil {
+			createdAt = time.Now().UTC()
+			data.Set("created_at", createdAt.Format(time.RFC3339))
+		}
+		data.Set("expires_at", apiKeyExpiration(createdAt, data.Get("rotation_days").(int)))
+	}
 	return apiKeyRead(ctx, data, meta)
 }
 
 func apiKeyDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	session := meta.(*ccloudapi.Session)
+	session := meta.(*providerMeta).session
 	environmentID := data.Get("environment_id").(string)
 	clusterID := data.Get("cluster_id").(string)
-	_, err := ccloudapi.DeleteAPIKey(environmentID, clusterID, data.Id(), session)
-	if err != nil {
+	id, _ := strconv.Atoi(data.Id())
+	err := deleteAPIKey(ctx, session, environmentID, clusterID, id)
+	if err != nil && !isNotFound(err) {
 		return diag.FromErr(err)
 	}
+	if outputPath, ok := data.GetOk("secret_output_path"); ok {
+		err = removeSecretFile(outputPath.(string), data.Get("secret_checksum").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	data.SetId("")
 	return diags
 }
+
+// checkSecretPath makes sure the secret can be written to
+// the given path, so no API Key is created for nothing.
+func checkSecretPath(path string) error {
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.IsDir():
+		return fmt.Errorf("Unable to write the API Secret to %s: "+
+			"path is a directory", path)
+	case err != nil && !os.IsNotExist(err):
+		return fmt.Errorf("Unable to write the API Secret to %s: %v", path, err)
+	}
+	// The file is replaced rather than written in place, so
+	// only the directory it is in needs to be writable.
+	probe, err := ioutil.TempFile(filepath.Dir(path), ".ccloud-apikey-")
+	if err != nil {
+		return fmt.Errorf("Unable to write the API Secret to %s: %v", path, err)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
+// writeSecretFile writes the key and secret to a file only
+// readable by its owner, and returns the checksum of the file.
+func writeSecretFile(path, format string, apiKey *ccloudapi.APIKey) (string, error) {
+	var content []byte
+	switch format {
+	case "properties":
+		content = []byte(fmt.Sprintf("api.key=%s\napi.secret=%s\n",
+			apiKey.Key, apiKey.Secret))
+	default:
+		jsonContent, err := json.MarshalIndent(map[string]string{
+			"key":    apiKey.Key,
+			"secret": apiKey.Secret,
+		}, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		content = append(jsonContent, '\n')
+	}
+	// Writing in place would keep the permissions of an existing file
+	// while the secret is written, so it replaces the file instead.
+	file, err := ioutil.TempFile(filepath.Dir(path), ".ccloud-apikey-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return "", err
+	}
+	checksum := sha256.Sum256(content)
+	return hex.EncodeToString(checksum[:]), nil
+}
+
+// checkSecretFile warns when the file holding the secret is gone
+// or has changed, since the secret cannot be retrieved again.
+func checkSecretFile(path, checksum string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "API Secret file is missing",
+				Detail: fmt.Sprintf("The file %s holding the API Secret no "+
+					"longer exists. The secret cannot be retrieved again, so "+
+					"the API Key should be rotated", path),
+			})
+		}
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "API Secret file is unreadable",
+			Detail:   err.Error(),
+		})
+	}
+	currentChecksum := sha256.Sum256(content)
+	if hex.EncodeToString(currentChecksum[:]) != checksum {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "API Secret file has changed",
+			Detail: fmt.Sprintf("The content of %s no longer matches the "+
+				"one written when the API Key was created", path),
+		})
+	}
+	return diags
+}
+
+// removeSecretFile deletes the file holding the secret, unless it no
+// longer matches the checksum of this API Key. That happens when the
+// key replacing this one wrote its own secret to the same path.
+func removeSecretFile(path, checksum string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	currentChecksum := sha256.Sum256(content)
+	if hex.EncodeToString(currentChecksum[:]) != checksum {
+		log.Printf("[WARN] Keeping API Secret file %s, as it no longer "+
+			"holds the secret of the API Key being deleted", path)
+		return nil
+	}
+	return os.Remove(path)
+}
+
+// apiKeyCustomizeDiff plans the replacement of the
+// API Key once it is older than its rotation period.
+func apiKeyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	createdAt, err := time.Parse(time.RFC3339, diff.Get("created_at").(string))
+	if err != nil {
+		if diff.HasChange("rotation_days") {
+			return diff.SetNewComputed("expires_at")
+		}
+		return nil
+	}
+	rotationDays := diff.Get("rotation_days").(int)
+	if rotationDays > 0 && !time.Now().Before(createdAt.AddDate(0, 0, rotationDays)) {
+		if err := diff.SetNewComputed("expires_at"); err != nil {
+			return err
+		}
+		return diff.ForceNew("expires_at")
+	}
+	if diff.HasChange("rotation_days") {
+		return diff.SetNew("expires_at", apiKeyExpiration(createdAt, rotationDays))
+	}
+	return nil
+}
+
+// apiKeyExpiration returns when the API Key is due for
+// rotation, or an empty string if it is never rotated.
+func apiKeyExpiration(createdAt time.Time, rotationDays int) string {
+	if rotationDays <= 0 {
+		return ""
+	}
+	return createdAt.AddDate(0, 0, rotationDays).Format(time.RFC3339)
+}
+
+// resourceAPIKeyV0 is the schema used before API Keys could be
+// rotated, encrypted or written to a file. Keys from that time
+// keep their secret in the state and use the default file format.
+func resourceAPIKeyV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"environment_id": {Type: schema.TypeString, Required: true},
+			"cluster_id":     {Type: schema.TypeString, Required: true},
+			"key":            {Type: schema.TypeString, Optional: true, Computed: true},
+			"secret":         {Type: schema.TypeString, Optional: true, Computed: true, Sensitive: true},
+		},
+	}
+}
+
+func apiKeyStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	// The output format forces a new key, so it must match its
+	// default to avoid replacing every key when upgrading.
+	if _, ok := rawState["secret_output_format"]; !ok {
+		rawState["secret_output_format"] = secretOutputFormats[0]
+	}
+	return rawState, nil
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ccloudapi "github.com/riferrei/ccloud-sdk-go"
+)
+
+func TestAPIKeyStateUpgradeV0(t *testing.T) {
+	rawState := loadStateFixture(t, "apikey_v0.json")
+	upgradedState, err := apiKeyStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"id":                   "123456",
+		"environment_id":       "env-abc12",
+		"cluster_id":           "lkc-abc12",
+		"key":                  "ABCDEFGHIJKLMNOP",
+		"secret":               "abcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ01",
+		"secret_output_format": "json",
+	}
+	if !reflect.DeepEqual(upgradedState, expected) {
+		t.Fatalf("Expected %v but got %v", expected, upgradedState)
+	}
+}
+
+func TestAPIKeyUpgradeKeepsExistingKeys(t *testing.T) {
+	config := map[string]interface{}{
+		"environment_id": "env-abc12",
+		"cluster_id":     "lkc-abc12",
+	}
+	diff := planAfterUpgrade(t, resourceAPIKey(),
+		loadStateFixture(t, "apikey_v0.json"), config)
+	if diff != nil && diff.RequiresNew() {
+		t.Fatalf("Upgraded API Key would be replaced: %v", diff)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("Upgraded API Key would be changed: %v", diff)
+	}
+}
+
+func TestSecretFileRemovedOnlyWhenUnchanged(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ccloud-apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "secret.json")
+	oldChecksum, err := writeSecretFile(path, "json",
+		&ccloudapi.APIKey{Key: "OLD", Secret: "old-secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The replacing key writes the same path before the old one is deleted
+	newChecksum, err := writeSecretFile(path, "json",
+		&ccloudapi.APIKey{Key: "NEW", Secret: "new-secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := removeSecretFile(path, oldChecksum); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Secret of the replacing key was removed: %v", err)
+	}
+	if err := removeSecretFile(path, newChecksum); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("Secret file was not removed: %v", err)
+	}
+	if err := removeSecretFile(path, newChecksum); err != nil {
+		t.Fatalf("Removing a missing secret file failed: %v", err)
+	}
+}
+
+func TestSecretFileOnlyReadableByOwner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ccloud-apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "secret.json")
+	if err := ioutil.WriteFile(path, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The link keeps what is written to the existing file
+	link := filepath.Join(dir, "link.json")
+	if err := os.Link(path, link); err != nil {
+		t.Fatal(err)
+	}
+	_, err = writeSecretFile(path, "json", &ccloudapi.APIKey{Key: "KEY", Secret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("Expected mode 0600 but got %v", info.Mode().Perm())
+	}
+	if content, _ := ioutil.ReadFile(link); string(content) != "{}\n" {
+		t.Fatalf("Secret was written to the readable file: %s", content)
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 2 {
+		t.Fatalf("Writing the secret left %d files behind", len(files)-2)
+	}
+}
+
+func TestCheckSecretPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ccloud-apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := checkSecretPath(filepath.Join(dir, "secret.json")); err != nil {
+		t.Fatalf("Writable path was rejected: %v", err)
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) > 0 {
+		t.Fatalf("Checking the path left %d files behind", len(files))
+	}
+	if err := checkSecretPath(dir); err == nil {
+		t.Fatal("Directory was accepted as the secret path")
+	}
+	if err := checkSecretPath(filepath.Join(dir, "missing", "secret.json")); err == nil {
+		t.Fatal("Path in a missing directory was accepted")
+	}
+}
+
+func TestAPIKeyOwner(t *testing.T) {
+	fake, session := newFakeCCloudAPI(t)
+	meta := &providerMeta{session: session}
+	apiKeyResource := resourceAPIKey()
+
+	// Keys are owned by the user of the provider by default
+	config := map[string]interface{}{
+		"environment_id": testEnvironmentID,
+		"cluster_id":     testClusterID,
+	}
+	state := applyConfig(t, apiKeyResource, nil, config, meta)
+	if state.Attributes["owner_id"] != strconv.Itoa(testUserID) {
+		t.Fatalf("Expected owner %d but got %s", testUserID, state.Attributes["owner_id"])
+	}
+
+	// Keys of service accounts are created on their behalf
+	config["owner_id"] = "2000"
+	diff, err := apiKeyResource.Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.RequiresNew() {
+		t.Fatal("Changing the owner of an API Key doesn't replace it")
+	}
+	_, diags := apiKeyResource.Apply(context.Background(), state,
+		&terraform.InstanceDiff{Destroy: true}, meta)
+	if diags.HasError() {
+		t.Fatalf("Unable to delete API Key: %v", diags)
+	}
+	if fake.apiKey(1) != nil {
+		t.Fatal("API Key was not deleted")
+	}
+	state = applyConfig(t, apiKeyResource, nil, config, meta)
+	if apiKey := fake.apiKey(2); apiKey == nil || apiKey.OwnerID != 2000 {
+		t.Fatalf("API Key was not created for the service account: %+v", apiKey)
+	}
+
+	// The owner is read back, so unchanged keys are left alone
+	state, diags = apiKeyResource.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("Unable to read API Key: %v", diags)
+	}
+	if state.Attributes["owner_id"] != "2000" {
+		t.Fatalf("Expected owner 2000 but got %s", state.Attributes["owner_id"])
+	}
+	diff, err = apiKeyResource.Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("Unchanged API Key would be updated: %v", diff)
+	}
+
+	if warns, errs := apiKeyResource.Schema["owner_id"].ValidateFunc("sa-123", "owner_id"); len(errs) == 0 {
+		t.Fatalf("Invalid owner was accepted: %v", warns)
+	}
+}
+
+func TestAPIKeyDescription(t *testing.T) {
+	fake, session := newFakeCCloudAPI(t)
+	meta := &providerMeta{session: session}
+	apiKeyResource := resourceAPIKey()
+
+	config := map[string]interface{}{
+		"environment_id": testEnvironmentID,
+		"cluster_id":     testClusterID,
+		"description":    "orders-service",
+	}
+	state := applyConfig(t, apiKeyResource, nil, config, meta)
+	if apiKey := fake.apiKey(1); apiKey == nil || apiKey.Description != "orders-service" {
+		t.Fatalf("API Key was not created with its description: %+v", apiKey)
+	}
+
+	// The description is updated in place, keeping the secret valid
+	config["description"] = "billing-service"
+	state = applyConfig(t, apiKeyResource, state, config, meta)
+	if state.ID != "1" {
+		t.Fatalf("API Key was replaced by %s", state.ID)
+	}
+	if description := fake.apiKey(1).Description; description != "billing-service" {
+		t.Fatalf("Expected description billing-service but got %s", description)
+	}
+
+	// Descriptions changed outside Terraform are read back
+	fake.apiKey(1).Description = "changed"
+	state, diags := apiKeyResource.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("Unable to read API Key: %v", diags)
+	}
+	if state.Attributes["description"] != "changed" {
+		t.Fatalf("Expected description changed but got %s", state.Attributes["description"])
+	}
+
+	delete(config, "description")
+	state = applyConfig(t, apiKeyResource, state, config, meta)
+	if description := fake.apiKey(1).Description; description != "" {
+		t.Fatalf("Description was not removed: %s", description)
+	}
+}
+
+func TestAPIKeySecretEncrypted(t *testing.T) {
+	_, session := newFakeCCloudAPI(t)
+	meta := &providerMeta{session: session}
+	entity := newTestPGPEntity(t, "Ops", "ops@example.com")
+	config := map[string]interface{}{
+		"environment_id": testEnvironmentID,
+		"cluster_id":     testClusterID,
+		"pgp_key":        base64.StdEncoding.EncodeToString(publicKeyring(t, entity)),
+	}
+	state := applyConfig(t, resourceAPIKey(), nil, config, meta)
+	// The secret must never be kept in clear text alongside a PGP key
+	if secret, ok := state.Attributes["secret"]; ok && secret != "" {
+		t.Fatalf("Secret was stored in clear text: %s", secret)
+	}
+	if fingerprint := hex.EncodeToString(entity.PrimaryKey.Fingerprint[:]); state.Attributes["key_fingerprint"] != fingerprint {
+		t.Fatalf("Expected fingerprint %s but got %s", fingerprint, state.Attributes["key_fingerprint"])
+	}
+	if secret := decryptWithPGPKey(t, entity, state.Attributes["encrypted_secret"]); secret != "secret-1" {
+		t.Fatalf("Expected secret-1 but decrypted %s", secret)
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -76,6 +78,14 @@ func resourceCluster() *schema.Resource {
 		ReadContext:   clusterRead,
 		UpdateContext: clusterUpdate,
 		DeleteContext: clusterDelete,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceClusterV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: clusterStateUpgradeV0,
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"environment_id": {
 				Type:     schema.TypeString,
@@ -236,13 +246,29 @@ func resourceCluster() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"bootstrap_servers": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bootstrap_host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bootstrap_port": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"kafka_rest_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func clusterCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	session := meta.(*ccloudapi.Session)
+	session := meta.(*providerMeta).session
 	cluster := &ccloudapi.Cluster{
 		EnvironmentID:  data.Get("environment_id").(string),
 		Name:           data.Get("name").(string),
@@ -269,6 +295,8 @@ func clusterCreate(ctx context.Context, data *schema.ResourceData, meta interfac
 	data.Set("organization_id", createdCluster.OrganizationID)
 	data.Set("cluster_endpoint", createdCluster.ClusterEndpoint)
 	data.Set("api_endpoint", createdCluster.APIEndpoint)
+	data.Set("kafka_rest_endpoint", kafkaRESTEndpoint(createdCluster.APIEndpoint))
+	setBootstrapAttributes(data, createdCluster.ClusterEndpoint)
 	return diags
 }
 
@@ -276,7 +304,7 @@ func clusterRead(ctx context.Context, data *schema.ResourceData, meta interface{
 	var diags diag.Diagnostics
 	id := data.Id()
 	environmentID := data.Get("environment_id").(string)
-	session := meta.(*ccloudapi.Session)
+	session := meta.(*providerMeta).session
 	cluster, err := ccloudapi.ReadCluster(id, environmentID, session)
 	if err != nil {
 		return diag.FromErr(err)
@@ -296,27 +324,57 @@ func clusterRead(ctx context.Context, data *schema.ResourceData, meta interface{
 	data.Set("organization_id", cluster.OrganizationID)
 	data.Set("cluster_endpoint", cluster.ClusterEndpoint)
 	data.Set("api_endpoint", cluster.APIEndpoint)
+	data.Set("kafka_rest_endpoint", kafkaRESTEndpoint(cluster.APIEndpoint))
+	setBootstrapAttributes(data, cluster.ClusterEndpoint)
 	return diags
 }
 
 func clusterUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, attribute := range []string{"organization_id", "cluster_endpoint", "api_endpoint"} {
+		if data.HasChange(attribute) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unsupported cluster change",
+				Detail: fmt.Sprintf("The attribute '%s' is managed by "+
+					"Confluent Cloud and cannot be changed", attribute),
+			})
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+	if !data.HasChange("name") {
+		return clusterRead(ctx, data, meta)
+	}
+	id := data.Id()
+	environmentID := data.Get("environment_id").(string)
+	name := data.Get("name").(string)
 	cluster := &ccloudapi.Cluster{
-		ID:             data.Id(),
-		EnvironmentID:  data.Get("environment_id").(string),
-		Name:           data.Get("name").(string),
-		CloudProvider:  data.Get("cloud_provider").(string),
-		CloudRegion:    data.Get("cloud_region").(string),
-		NetworkIngress: data.Get("network_ingress").(int),
-		NetworkEgress:  data.Get("network_egress").(int),
-		Storage:        data.Get("storage").(int),
-		Durability:     data.Get("durability").(string),
-		OrganizationID: data.Get("organization_id").(int),
+		ID:            id,
+		EnvironmentID: environmentID,
+		Name:          name,
+	}
+	session := meta.(*providerMeta).session
+	updated, err := ccloudapi.UpdateCluster(cluster, session)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !updated {
+		return diag.Errorf("Unable to rename cluster %s: cluster not found", id)
 	}
-	session := meta.(*ccloudapi.Session)
-	_, err := ccloudapi.UpdateCluster(cluster, session)
+	updatedCluster, err := ccloudapi.ReadCluster(id, environmentID, session)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if updatedCluster == nil {
+		return diag.Errorf("Unable to rename cluster %s: cluster not found", id)
+	}
+	if updatedCluster.Name != name {
+		return diag.Errorf("Confluent Cloud accepted the rename of cluster %s "+
+			"to '%s' but the cluster is still named '%s'", id, name,
+			updatedCluster.Name)
+	}
 	return clusterRead(ctx, data, meta)
 }
 
@@ -334,7 +392,7 @@ func clusterDelete(ctx context.Context, data *schema.ResourceData, meta interfac
 		Durability:     data.Get("durability").(string),
 		OrganizationID: data.Get("organization_id").(int),
 	}
-	session := meta.(*ccloudapi.Session)
+	session := meta.(*providerMeta).session
 	_, err := ccloudapi.DeleteCluster(cluster, session)
 	if err != nil {
 		return diag.FromErr(err)
@@ -342,3 +400,62 @@ func clusterDelete(ctx context.Context, data *schema.ResourceData, meta interfac
 	data.SetId("")
 	return diags
 }
+
+// setBootstrapAttributes breaks down the cluster endpoint, which comes
+// in the form of SASL_SSL://host:port, into its individual parts.
+func setBootstrapAttributes(data *schema.ResourceData, clusterEndpoint string) {
+	bootstrapServers, host, port := splitClusterEndpoint(clusterEndpoint)
+	data.Set("bootstrap_servers", bootstrapServers)
+	data.Set("bootstrap_host", host)
+	data.Set("bootstrap_port", port)
+}
+
+func splitClusterEndpoint(clusterEndpoint string) (string, string, int) {
+	bootstrapServers := clusterEndpoint
+	if index := strings.Index(bootstrapServers, "://"); index >= 0 {
+		bootstrapServers = bootstrapServers[index+3:]
+	}
+	host, port, err := net.SplitHostPort(bootstrapServers)
+	if err != nil {
+		return bootstrapServers, bootstrapServers, 0
+	}
+	portNumber, _ := strconv.Atoi(port)
+	return bootstrapServers, host, portNumber
+}
+
+// kafkaRESTEndpoint returns the base URL of the Kafka REST API (v3)
+// of a cluster, which Confluent Cloud serves from its API endpoint.
+func kafkaRESTEndpoint(apiEndpoint string) string {
+	return strings.TrimSuffix(apiEndpoint, "/")
+}
+
+// resourceClusterV0 is the schema used before the bootstrap
+// attributes were derived from the cluster endpoint.
+func resourceClusterV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"environment_id":   {Type: schema.TypeString, Required: true},
+			"name":             {Type: schema.TypeString, Required: true},
+			"cloud_provider":   {Type: schema.TypeString, Required: true},
+			"cloud_region":     {Type: schema.TypeString, Required: true},
+			"network_ingress":  {Type: schema.TypeInt, Optional: true},
+			"network_egress":   {Type: schema.TypeInt, Optional: true},
+			"storage":          {Type: schema.TypeInt, Optional: true},
+			"durability":       {Type: schema.TypeString, Optional: true},
+			"organization_id":  {Type: schema.TypeInt, Optional: true, Computed: true},
+			"cluster_endpoint": {Type: schema.TypeString, Optional: true, Computed: true},
+			"api_endpoint":     {Type: schema.TypeString, Optional: true, Computed: true},
+		},
+	}
+}
+
+func clusterStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	clusterEndpoint, _ := rawState["cluster_endpoint"].(string)
+	bootstrapServers, host, port := splitClusterEndpoint(clusterEndpoint)
+	rawState["bootstrap_servers"] = bootstrapServers
+	rawState["bootstrap_host"] = host
+	rawState["bootstrap_port"] = port
+	apiEndpoint, _ := rawState["api_endpoint"].(string)
+	rawState["kafka_rest_endpoint"] = kafkaRESTEndpoint(apiEndpoint)
+	return rawState, nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceClusterConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: clusterConfigCreate,
+		ReadContext:   clusterConfigRead,
+		UpdateContext: clusterConfigUpdate,
+		DeleteContext: clusterConfigDelete,
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"credentials": kafkaCredentialsSchema(),
+			"config": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ValidateFunc: func(v interface{}, k string) (wrs []string, ers []error) {
+					configs, _ := v.(map[string]interface{})
+					return validateConfigs(clusterConfigs, "cluster", true, configs)
+				},
+			},
+		},
+	}
+}
+
+func clusterConfigCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configs := expandStringMap(data.Get("config").(map[string]interface{}))
+	err = client.AlterBrokerConfigs(ctx, configs, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(client.clusterID)
+	data.Set("cluster_id", client.clusterID)
+	return clusterConfigRead(ctx, data, meta)
+}
+
+func clusterConfigRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	brokerConfigs, err := client.ListBrokerConfigs(ctx)
+	if err != nil {
+		if isKafkaRESTNotFound(err) {
+			data.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	// Only the configs set explicitly are tracked, so
+	// the defaults of the cluster don't show up as drift.
+	trackedConfigs := data.Get("config").(map[string]interface{})
+	configs := map[string]string{}
+	for _, brokerConfig := range brokerConfigs {
+		if _, ok := trackedConfigs[brokerConfig.Name]; !ok {
+			continue
+		}
+		if brokerConfig.IsDefault || brokerConfig.Value == nil {
+			continue
+		}
+		configs[brokerConfig.Name] = *brokerConfig.Value
+	}
+	data.Set("config", configs)
+	return diags
+}
+
+func clusterConfigUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if data.HasChange("config") {
+		client, err := kafkaRESTClientFromData(data, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		oldConfigs, newConfigs := data.GetChange("config")
+		set, reset := diffStringMaps(oldConfigs.(map[string]interface{}),
+			newConfigs.(map[string]interface{}))
+		err = client.AlterBrokerConfigs(ctx, set, reset)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return clusterConfigRead(ctx, data, meta)
+}
+
+func clusterConfigDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	reset := []string{}
+	for name := range data.Get("config").(map[string]interface{}) {
+		reset = append(reset, name)
+	}
+	err = client.AlterBrokerConfigs(ctx, nil, reset)
+	if err != nil && !isKafkaRESTNotFound(err) {
+		return diag.FromErr(err)
+	}
+	data.SetId("")
+	return diags
+}
+
+func expandStringMap(m map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(m))
+	for key, value := range m {
+		result[key] = value.(string)
+	}
+	return result
+}
+
+// diffStringMaps returns the entries that were added or changed
+// from oldMap to newMap, plus the keys that were removed.
+func diffStringMaps(oldMap, newMap map[string]interface{}) (map[string]string, []string) {
+	set := map[string]string{}
+	reset := []string{}
+	for key, value := range newMap {
+		if oldValue, ok := oldMap[key]; !ok || oldValue != value {
+			set[key] = value.(string)
+		}
+	}
+	for key := range oldMap {
+		if _, ok := newMap[key]; !ok {
+			reset = append(reset, key)
+		}
+	}
+	return set, reset
+}
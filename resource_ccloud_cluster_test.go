@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestClusterStateUpgradeV0(t *testing.T) {
+	rawState := loadStateFixture(t, "cluster_v0.json")
+	upgradedState, err := clusterStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := loadStateFixture(t, "cluster_v0.json")
+	expected["bootstrap_servers"] = "pkc-abc12.westus2.azure.confluent.cloud:9092"
+	expected["bootstrap_host"] = "pkc-abc12.westus2.azure.confluent.cloud"
+	expected["bootstrap_port"] = 9092
+	expected["kafka_rest_endpoint"] = "https://pkac-abc12.westus2.azure.confluent.cloud"
+	if !reflect.DeepEqual(upgradedState, expected) {
+		t.Fatalf("Expected %v but got %v", expected, upgradedState)
+	}
+}
+
+func TestClusterUpgradeKeepsExistingClusters(t *testing.T) {
+	config := map[string]interface{}{
+		"environment_id": "env-abc12",
+		"name":           "new-cluster",
+		"cloud_provider": "azure",
+		"cloud_region":   "westus2",
+	}
+	diff := planAfterUpgrade(t, resourceCluster(),
+		loadStateFixture(t, "cluster_v0.json"), config)
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("Upgraded cluster would be changed: %v", diff)
+	}
+}
+
+func TestSplitClusterEndpoint(t *testing.T) {
+	cases := []struct {
+		clusterEndpoint  string
+		bootstrapServers string
+		host             string
+		port             int
+	}{
+		{"SASL_SSL://pkc-abc12.westus2.azure.confluent.cloud:9092",
+			"pkc-abc12.westus2.azure.confluent.cloud:9092",
+			"pkc-abc12.westus2.azure.confluent.cloud", 9092},
+		{"pkc-abc12.westus2.azure.confluent.cloud:9092",
+			"pkc-abc12.westus2.azure.confluent.cloud:9092",
+			"pkc-abc12.westus2.azure.confluent.cloud", 9092},
+		{"", "", "", 0},
+	}
+	for _, c := range cases {
+		bootstrapServers, host, port := splitClusterEndpoint(c.clusterEndpoint)
+		if bootstrapServers != c.bootstrapServers || host != c.host || port != c.port {
+			t.Errorf("Expected %s, %s and %d from '%s' but got %s, %s and %d",
+				c.bootstrapServers, c.host, c.port, c.clusterEndpoint,
+				bootstrapServers, host, port)
+		}
+	}
+}
@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	ccloudapi "github.com/riferrei/ccloud-sdk-go"
 )
@@ -14,6 +18,17 @@ func resourceEnvironment() *schema.Resource {
 		ReadContext:   environmentRead,
 		UpdateContext: environmentUpdate,
 		DeleteContext: environmentDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceEnvironmentV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: environmentStateUpgradeV0,
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -24,13 +39,18 @@ func resourceEnvironment() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
 func environmentCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	session := meta.(*ccloudapi.Session)
+	session := meta.(*providerMeta).session
 	environment := &ccloudapi.Environment{
 		OrganizationID: session.User.OrganizationID,
 		Name:           data.Get("name").(string),
@@ -48,8 +68,11 @@ func environmentCreate(ctx context.Context, data *schema.ResourceData, meta inte
 func environmentRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	id := data.Id()
-	session := meta.(*ccloudapi.Session)
-	environment, _ := ccloudapi.ReadEnvironment(id, session)
+	session := meta.(*providerMeta).session
+	environment, err := ccloudapi.ReadEnvironment(id, session)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
 	if environment == nil {
 		data.SetId("")
 		return diags
@@ -60,13 +83,36 @@ func environmentRead(ctx context.Context, data *schema.ResourceData, meta interf
 }
 
 func environmentUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !data.HasChange("name") {
+		return environmentRead(ctx, data, meta)
+	}
+	id := data.Id()
+	name := data.Get("name").(string)
 	environment := &ccloudapi.Environment{
-		ID:             data.Id(),
-		Name:           data.Get("name").(string),
+		ID:             id,
+		Name:           name,
 		OrganizationID: data.Get("organization_id").(int),
 	}
-	session := meta.(*ccloudapi.Session)
-	ccloudapi.UpdateEnvironment(environment, session)
+	session := meta.(*providerMeta).session
+	updated, err := ccloudapi.UpdateEnvironment(environment, session)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !updated {
+		return diag.Errorf("Unable to rename environment %s: environment not found", id)
+	}
+	updatedEnvironment, err := ccloudapi.ReadEnvironment(id, session)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if updatedEnvironment == nil {
+		return diag.Errorf("Unable to rename environment %s: environment not found", id)
+	}
+	if updatedEnvironment.Name != name {
+		return diag.Errorf("Confluent Cloud accepted the rename of environment %s "+
+			"to '%s' but the environment is still named '%s'", id, name,
+			updatedEnvironment.Name)
+	}
 	return environmentRead(ctx, data, meta)
 }
 
@@ -77,8 +123,92 @@ func environmentDelete(ctx context.Context, data *schema.ResourceData, meta inte
 		Name:           data.Get("name").(string),
 		OrganizationID: data.Get("organization_id").(int),
 	}
-	session := meta.(*ccloudapi.Session)
-	ccloudapi.DeleteEnvironment(environment, session)
+	session := meta.(*providerMeta).session
+	clusters, err := ccloudapi.ListClusters(environment.ID, session)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+	if len(clusters) > 0 {
+		if !data.Get("force_destroy").(bool) {
+			clusterNames := []string{}
+			for _, cluster := range clusters {
+				clusterNames = append(clusterNames,
+					fmt.Sprintf("%s (%s)", cluster.Name, cluster.ID))
+			}
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Environment is not empty",
+					Detail: fmt.Sprintf("Environment %s still contains the "+
+						"following clusters, along with their API Keys: %s. "+
+						"Delete them first or set force_destroy = true",
+						environment.ID, strings.Join(clusterNames, ", ")),
+				},
+			}
+		}
+		err = deleteClusters(ctx, clusters, session, data.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	_, err = ccloudapi.DeleteEnvironment(environment, session)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
 	data.SetId("")
 	return diags
 }
+
+// deleteClusters deletes each cluster of an environment, which
+// also deletes their API Keys, and waits for them to disappear.
+// The timeout applies to all clusters together, not to each one.
+func deleteClusters(ctx context.Context, clusters []*ccloudapi.Cluster,
+	session *ccloudapi.Session, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for _, cluster := range clusters {
+		_, err := ccloudapi.DeleteCluster(cluster, session)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("Timed out after %s while deleting the "+
+				"clusters of environment %s", timeout, cluster.EnvironmentID)
+		}
+		err = resource.RetryContext(ctx, remaining, func() *resource.RetryError {
+			remainingCluster, err := ccloudapi.ReadCluster(cluster.ID, cluster.EnvironmentID, session)
+			if err != nil && !isNotFound(err) {
+				return resource.NonRetryableError(err)
+			}
+			if remainingCluster != nil {
+				return resource.RetryableError(fmt.Errorf("Cluster %s "+
+					"is still being deleted", cluster.ID))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceEnvironmentV0 is the schema used before environments
+// had force_destroy, when deleting one never touched its clusters.
+func resourceEnvironmentV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":            {Type: schema.TypeString, Required: true},
+			"organization_id": {Type: schema.TypeInt, Optional: true, Computed: true},
+		},
+	}
+}
+
+func environmentStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	// Environments created before force_destroy existed are
+	// guarded, so the upgrade must not plan a change for it.
+	if _, ok := rawState["force_destroy"]; !ok {
+		rawState["force_destroy"] = false
+	}
+	return rawState, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestEnvironmentStateUpgradeV0(t *testing.T) {
+	rawState := loadStateFixture(t, "environment_v0.json")
+	upgradedState, err := environmentStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"id":              "env-abc12",
+		"name":            "new-env-name",
+		"organization_id": float64(12345),
+		"force_destroy":   false,
+	}
+	if !reflect.DeepEqual(upgradedState, expected) {
+		t.Fatalf("Expected %v but got %v", expected, upgradedState)
+	}
+}
+
+func TestEnvironmentUpgradeKeepsExistingEnvironments(t *testing.T) {
+	config := map[string]interface{}{
+		"name": "new-env-name",
+	}
+	diff := planAfterUpgrade(t, resourceEnvironment(),
+		loadStateFixture(t, "environment_v0.json"), config)
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("Upgraded environment would be changed: %v", diff)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultPartitionsCount = 6
+	// Topic configs set explicitly, as opposed to inherited ones
+	topicConfigSource = "DYNAMIC_TOPIC_CONFIG"
+)
+
+func resourceKafkaTopic() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: kafkaTopicCreate,
+		ReadContext:   kafkaTopicRead,
+		UpdateContext: kafkaTopicUpdate,
+		DeleteContext: kafkaTopicDelete,
+		CustomizeDiff: kafkaTopicCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: kafkaTopicImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"credentials": kafkaCredentialsSchema(),
+			"topic_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (wrs []string, ers []error) {
+					value, _ := v.(string)
+					return validateTopicName(value)
+				},
+			},
+			"partitions_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultPartitionsCount,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"config": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ValidateFunc: func(v interface{}, k string) (wrs []string, ers []error) {
+					configs, _ := v.(map[string]interface{})
+					return validateConfigs(topicConfigs, "topic", false, configs)
+				},
+			},
+			"replication_factor": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func kafkaTopicCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := data.Get("topic_name").(string)
+	configs := expandStringMap(data.Get("config").(map[string]interface{}))
+	_, err = client.CreateTopic(ctx, name, data.Get("partitions_count").(int), configs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(kafkaTopicID(client.clusterID, name))
+	data.Set("cluster_id", client.clusterID)
+	return kafkaTopicRead(ctx, data, meta)
+}
+
+func kafkaTopicRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := data.Get("topic_name").(string)
+	topic, err := client.ReadTopic(ctx, name)
+	if err != nil {
+		if isKafkaRESTNotFound(err) {
+			data.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	topicConfigs, err := client.ListTopicConfigs(ctx, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configs := map[string]string{}
+	for _, topicConfig := range topicConfigs {
+		if topicConfig.Source == topicConfigSource && topicConfig.Value != nil {
+			configs[topicConfig.Name] = *topicConfig.Value
+		}
+	}
+	data.Set("topic_name", topic.TopicName)
+	data.Set("partitions_count", topic.PartitionsCount)
+	data.Set("replication_factor", topic.ReplicationFactor)
+	data.Set("config", configs)
+	return diags
+}
+
+func kafkaTopicUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := data.Get("topic_name").(string)
+	if data.HasChange("partitions_count") {
+		err = client.UpdatePartitionsCount(ctx, name, data.Get("partitions_count").(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if data.HasChange("config") {
+		oldConfigs, newConfigs := data.GetChange("config")
+		set, reset := diffStringMaps(oldConfigs.(map[string]interface{}),
+			newConfigs.(map[string]interface{}))
+		err = client.AlterTopicConfigs(ctx, name, set, reset)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return kafkaTopicRead(ctx, data, meta)
+}
+
+func kafkaTopicDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = client.DeleteTopic(ctx, data.Get("topic_name").(string))
+	if err != nil && !isKafkaRESTNotFound(err) {
+		return diag.FromErr(err)
+	}
+	data.SetId("")
+	return diags
+}
+
+// kafkaTopicCustomizeDiff rejects decreasing the number of
+// partitions, which Kafka doesn't support, at plan time.
+func kafkaTopicCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("partitions_count") {
+		return nil
+	}
+	oldCount, newCount := diff.GetChange("partitions_count")
+	if newCount.(int) < oldCount.(int) {
+		return fmt.Errorf("Unable to decrease the partitions of topic '%s' "+
+			"from %d to %d. The number of partitions can only be increased",
+			diff.Get("topic_name"), oldCount, newCount)
+	}
+	return nil
+}
+
+// kafkaTopicImport takes an ID in the form of <cluster ID>/<topic
+// name>, or <environment ID>/<cluster ID>/<topic name> for clusters
+// whose REST endpoint is not set in the kafka block of the provider.
+// The API Key of the cluster comes from the provider as well.
+func kafkaTopicImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(data.Id(), "/", 3)
+	for _, part := range parts {
+		if len(part) == 0 {
+			parts = nil
+			break
+		}
+	}
+	switch len(parts) {
+	case 2:
+		data.Set("cluster_id", parts[0])
+		data.Set("topic_name", parts[1])
+	case 3:
+		data.Set("environment_id", parts[0])
+		data.Set("cluster_id", parts[1])
+		data.Set("topic_name", parts[2])
+	default:
+		return nil, fmt.Errorf("Invalid topic ID '%s'. Expected <cluster "+
+			"ID>/<topic name> or <environment ID>/<cluster ID>/<topic name>",
+			data.Id())
+	}
+	data.SetId(kafkaTopicID(data.Get("cluster_id").(string),
+		data.Get("topic_name").(string)))
+	return []*schema.ResourceData{data}, nil
+}
+
+func kafkaTopicID(clusterID, name string) string {
+	return clusterID + "/" + name
+}
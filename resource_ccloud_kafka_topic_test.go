@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// applyConfig plans the config against the state and applies
+// the plan, the same way Terraform does on terraform apply.
+func applyConfig(t *testing.T, resource *schema.Resource, state *terraform.InstanceState,
+	config map[string]interface{}, meta interface{}) *terraform.InstanceState {
+	t.Helper()
+	diff, err := resource.Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("Unable to plan: %v", err)
+	}
+	if diff == nil {
+		return state
+	}
+	newState, diags := resource.Apply(context.Background(), state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("Unable to apply: %v", diags)
+	}
+	return newState
+}
+
+func TestKafkaTopicLifecycle(t *testing.T) {
+	fake := newFakeKafkaREST(t)
+	meta := &providerMeta{kafka: fake.kafkaSettings()}
+	topicResource := resourceKafkaTopic()
+
+	// Create
+	config := map[string]interface{}{
+		"topic_name":       "orders",
+		"partitions_count": 3,
+		"config": map[string]interface{}{
+			"cleanup.policy": "compact",
+		},
+	}
+	state := applyConfig(t, topicResource, nil, config, meta)
+	if state.ID != testClusterID+"/orders" {
+		t.Fatalf("Expected ID %s/orders but got %s", testClusterID, state.ID)
+	}
+	topic := fake.topic("orders")
+	if topic == nil || topic.partitionsCount != 3 || topic.configs["cleanup.policy"] != "compact" {
+		t.Fatalf("Topic was not created as configured: %+v", topic)
+	}
+	if state.Attributes["replication_factor"] != "3" || state.Attributes["cluster_id"] != testClusterID {
+		t.Fatalf("Created topic was not read back: %v", state.Attributes)
+	}
+	if _, ok := state.Attributes["config.retention.ms"]; ok {
+		t.Fatal("Default configs of the topic ended up in the state")
+	}
+
+	// Read with no changes
+	state, diags := topicResource.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("Unable to read topic: %v", diags)
+	}
+	diff, err := topicResource.Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("Unchanged topic would be updated: %v", diff)
+	}
+
+	// Partition increase, plus setting one config and resetting another
+	config["partitions_count"] = 6
+	config["config"] = map[string]interface{}{
+		"retention.ms": "86400000",
+	}
+	state = applyConfig(t, topicResource, state, config, meta)
+	topic = fake.topic("orders")
+	if topic.partitionsCount != 6 {
+		t.Fatalf("Expected 6 partitions but got %d", topic.partitionsCount)
+	}
+	expectedConfigs := map[string]string{"retention.ms": "86400000"}
+	if !reflect.DeepEqual(topic.configs, expectedConfigs) {
+		t.Fatalf("Expected configs %v but got %v", expectedConfigs, topic.configs)
+	}
+	if state.Attributes["partitions_count"] != "6" || state.Attributes["config.retention.ms"] != "86400000" {
+		t.Fatalf("Updated topic was not read back: %v", state.Attributes)
+	}
+
+	// Decreasing partitions is rejected at plan time
+	config["partitions_count"] = 2
+	_, err = topicResource.Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(config), meta)
+	if err == nil {
+		t.Fatal("Decreasing the partitions of a topic was planned")
+	}
+	config["partitions_count"] = 6
+
+	// Import
+	importData := topicResource.Data(&terraform.InstanceState{ID: testClusterID + "/orders"})
+	imported, err := topicResource.Importer.StateContext(context.Background(), importData, meta)
+	if err != nil {
+		t.Fatalf("Unable to import topic: %v", err)
+	}
+	importedState, diags := topicResource.RefreshWithoutUpgrade(context.Background(),
+		imported[0].State(), meta)
+	if diags.HasError() {
+		t.Fatalf("Unable to read imported topic: %v", diags)
+	}
+	for _, attribute := range []string{"id", "cluster_id", "topic_name", "partitions_count", "config.retention.ms"} {
+		if importedState.Attributes[attribute] != state.Attributes[attribute] {
+			t.Errorf("Imported %s is '%s' instead of '%s'", attribute,
+				importedState.Attributes[attribute], state.Attributes[attribute])
+		}
+	}
+
+	// Delete
+	_, diags = topicResource.Apply(context.Background(), state,
+		&terraform.InstanceDiff{Destroy: true}, meta)
+	if diags.HasError() {
+		t.Fatalf("Unable to delete topic: %v", diags)
+	}
+	if fake.topic("orders") != nil {
+		t.Fatal("Topic was not deleted")
+	}
+
+	// Topics deleted outside Terraform are removed from the state
+	state, diags = topicResource.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("Unable to read deleted topic: %v", diags)
+	}
+	if state != nil && state.ID != "" {
+		t.Fatalf("Deleted topic is still in the state: %v", state)
+	}
+}
+
+func TestKafkaTopicImportID(t *testing.T) {
+	cases := map[string][]string{
+		"lkc-abc12/orders":           {"", "lkc-abc12", "orders"},
+		"env-abc12/lkc-abc12/orders": {"env-abc12", "lkc-abc12", "orders"},
+		"orders":                     nil,
+		"lkc-abc12/":                 nil,
+	}
+	for id, expected := range cases {
+		data := resourceKafkaTopic().Data(&terraform.InstanceState{ID: id})
+		_, err := kafkaTopicImport(context.Background(), data, nil)
+		if expected == nil {
+			if err == nil {
+				t.Errorf("Invalid ID '%s' was accepted", id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Valid ID '%s' was rejected: %v", id, err)
+			continue
+		}
+		actual := []string{data.Get("environment_id").(string),
+			data.Get("cluster_id").(string), data.Get("topic_name").(string)}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Expected %v from '%s' but got %v", expected, id, actual)
+		}
+		if data.Id() != expected[1]+"/"+expected[2] {
+			t.Errorf("Expected ID %s/%s but got %s", expected[1], expected[2], data.Id())
+		}
+	}
+}
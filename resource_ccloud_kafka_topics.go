@@ -0,0 +1,483 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v2"
+)
+
+const defaultMaxConcurrency = 8
+
+// topicSpec is a topic as described in the spec file, which
+// is either YAML or JSON since the latter is a subset of YAML.
+type topicSpec struct {
+	Name       string            `yaml:"name"`
+	Partitions int               `yaml:"partitions"`
+	Config     map[string]string `yaml:"config"`
+}
+
+type topicsSpec struct {
+	Topics []*topicSpec `yaml:"topics"`
+}
+
+func resourceKafkaTopics() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: kafkaTopicsCreate,
+		ReadContext:   kafkaTopicsRead,
+		UpdateContext: kafkaTopicsUpdate,
+		DeleteContext: kafkaTopicsDelete,
+		CustomizeDiff: kafkaTopicsCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"credentials": kafkaCredentialsSchema(),
+			"spec": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (wrs []string, ers []error) {
+					value, _ := v.(string)
+					topics, err := parseTopicsSpec(value)
+					if err != nil {
+						return nil, []error{err}
+					}
+					// Errors are reported by the CustomizeDiff,
+					// which is unable to report warnings.
+					warns, _ := checkTopicSpecs(topics)
+					return warns, nil
+				},
+			},
+			"max_concurrency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxConcurrency,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"topic": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"partitions_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"config": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func kafkaTopicsCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	topics, err := parseTopicsSpec(data.Get("spec").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var mutex sync.Mutex
+	createdTopics := []*topicSpec{}
+	tasks := []func() error{}
+	for _, topic := range topics {
+		topic := topic
+		tasks = append(tasks, func() error {
+			_, err := client.CreateTopic(ctx, topic.Name, topic.Partitions, topic.Config)
+			if err != nil {
+				return topicError(topic.Name, err)
+			}
+			mutex.Lock()
+			createdTopics = append(createdTopics, topic)
+			mutex.Unlock()
+			return nil
+		})
+	}
+	err = runConcurrently(data.Get("max_concurrency").(int), tasks)
+	if err != nil && len(createdTopics) == 0 {
+		return diag.FromErr(err)
+	}
+	data.SetId(client.clusterID + "/" + resource.UniqueId())
+	data.Set("cluster_id", client.clusterID)
+	// Only the topics created here are tracked, so that topics
+	// that already existed are never deleted by this resource.
+	data.Set("topic", flattenTopicSpecs(createdTopics))
+	if err != nil {
+		return append(diag.FromErr(err), kafkaTopicsRead(ctx, data, meta)...)
+	}
+	return kafkaTopicsRead(ctx, data, meta)
+}
+
+func kafkaTopicsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	trackedTopics := expandTopicSpecs(data.Get("topic").(*schema.Set))
+	var mutex sync.Mutex
+	topics := []*topicSpec{}
+	tasks := []func() error{}
+	for _, trackedTopic := range trackedTopics {
+		name := trackedTopic.Name
+		tasks = append(tasks, func() error {
+			topic, err := readTopicSpec(ctx, client, name)
+			if err != nil {
+				if isKafkaRESTNotFound(err) {
+					return nil
+				}
+				return topicError(name, err)
+			}
+			mutex.Lock()
+			topics = append(topics, topic)
+			mutex.Unlock()
+			return nil
+		})
+	}
+	err = runConcurrently(data.Get("max_concurrency").(int), tasks)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.Set("topic", flattenTopicSpecs(topics))
+	return diags
+}
+
+func kafkaTopicsUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldTopics, _ := data.GetChange("topic")
+	trackedTopics := expandTopicSpecs(oldTopics.(*schema.Set))
+	currentTopics := map[string]*topicSpec{}
+	for _, topic := range trackedTopics {
+		currentTopics[topic.Name] = topic
+	}
+	desiredTopics, err := parseTopicsSpec(data.Get("spec").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var mutex sync.Mutex
+	createdTopics := []*topicSpec{}
+	deletedTopics := map[string]bool{}
+	tasks := []func() error{}
+	for _, desiredTopic := range desiredTopics {
+		desiredTopic := desiredTopic
+		currentTopic, ok := currentTopics[desiredTopic.Name]
+		delete(currentTopics, desiredTopic.Name)
+		if !ok {
+			tasks = append(tasks, func() error {
+				_, err := client.CreateTopic(ctx, desiredTopic.Name,
+					desiredTopic.Partitions, desiredTopic.Config)
+				if err != nil {
+					return topicError(desiredTopic.Name, err)
+				}
+				mutex.Lock()
+				createdTopics = append(createdTopics, desiredTopic)
+				mutex.Unlock()
+				return nil
+			})
+			continue
+		}
+		tasks = append(tasks, func() error {
+			err := updateTopicSpec(ctx, client, currentTopic, desiredTopic)
+			return topicError(desiredTopic.Name, err)
+		})
+	}
+	for name := range currentTopics {
+		name := name
+		tasks = append(tasks, func() error {
+			err := client.DeleteTopic(ctx, name)
+			if err != nil && !isKafkaRESTNotFound(err) {
+				return topicError(name, err)
+			}
+			mutex.Lock()
+			deletedTopics[name] = true
+			mutex.Unlock()
+			return nil
+		})
+	}
+	err = runConcurrently(data.Get("max_concurrency").(int), tasks)
+	// Topics stay tracked until they are deleted, and new ones are
+	// only tracked once created, so partial failures end up in the
+	// state without taking over topics that already existed.
+	remainingTopics := createdTopics
+	for _, topic := range trackedTopics {
+		if !deletedTopics[topic.Name] {
+			remainingTopics = append(remainingTopics, topic)
+		}
+	}
+	data.Set("topic", flattenTopicSpecs(remainingTopics))
+	if err != nil {
+		return append(diag.FromErr(err), kafkaTopicsRead(ctx, data, meta)...)
+	}
+	return kafkaTopicsRead(ctx, data, meta)
+}
+
+func kafkaTopicsDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client, err := kafkaRESTClientFromData(data, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tasks := []func() error{}
+	for _, topic := range expandTopicSpecs(data.Get("topic").(*schema.Set)) {
+		name := topic.Name
+		tasks = append(tasks, func() error {
+			err := client.DeleteTopic(ctx, name)
+			if err != nil && !isKafkaRESTNotFound(err) {
+				return topicError(name, err)
+			}
+			return nil
+		})
+	}
+	err = runConcurrently(data.Get("max_concurrency").(int), tasks)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId("")
+	return diags
+}
+
+// kafkaTopicsCustomizeDiff validates the spec at plan time and
+// plans the topics it describes, so each topic shows its own diff.
+func kafkaTopicsCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("spec") {
+		return diff.SetNewComputed("topic")
+	}
+	topics, err := parseTopicsSpec(diff.Get("spec").(string))
+	if err != nil {
+		return err
+	}
+	oldTopics, _ := diff.GetChange("topic")
+	currentTopics := map[string]*topicSpec{}
+	for _, topic := range expandTopicSpecs(oldTopics.(*schema.Set)) {
+		currentTopics[topic.Name] = topic
+	}
+	_, errs := checkTopicSpecs(topics)
+	errors := []string{}
+	for _, err := range errs {
+		errors = append(errors, err.Error())
+	}
+	for _, topic := range topics {
+		currentTopic, ok := currentTopics[topic.Name]
+		if ok && topic.Partitions < currentTopic.Partitions {
+			errors = append(errors, fmt.Sprintf("Unable to decrease the "+
+				"partitions of topic '%s' from %d to %d. The number of "+
+				"partitions can only be increased", topic.Name,
+				currentTopic.Partitions, topic.Partitions))
+		}
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("Invalid topics spec:\n%s", strings.Join(errors, "\n"))
+	}
+	return diff.SetNew("topic", flattenTopicSpecs(topics))
+}
+
+// checkTopicSpecs validates the names, partitions and configs of
+// the topics in a spec. Besides the warnings about each name, it
+// warns about names that collide in metrics within the spec itself,
+// as Kafka uses the same metric name for '.' and '_'.
+func checkTopicSpecs(topics []*topicSpec) ([]string, []error) {
+	var errors []error
+	var warns []string
+	metricNames := map[string][]string{}
+	for _, topic := range topics {
+		nameWarns, nameErrs := validateTopicName(topic.Name)
+		warns = append(warns, nameWarns...)
+		errors = append(errors, nameErrs...)
+		if topic.Partitions < 1 {
+			errors = append(errors, fmt.Errorf("Topic '%s' has %d partitions, "+
+				"but needs at least 1", topic.Name, topic.Partitions))
+		}
+		configs := map[string]interface{}{}
+		for name, value := range topic.Config {
+			configs[name] = value
+		}
+		configWarns, configErrs := validateConfigs(topicConfigs, "topic", false, configs)
+		for _, warn := range configWarns {
+			warns = append(warns, fmt.Sprintf("%s: %s", topic.Name, warn))
+		}
+		for _, err := range configErrs {
+			errors = append(errors, fmt.Errorf("%s: %s", topic.Name, err))
+		}
+		metricName := strings.ReplaceAll(topic.Name, ".", "_")
+		metricNames[metricName] = append(metricNames[metricName], topic.Name)
+	}
+	collisions := []string{}
+	for _, names := range metricNames {
+		if len(names) > 1 {
+			sort.Strings(names)
+			collisions = append(collisions, fmt.Sprintf("Topics '%s' collide "+
+				"due to limitations in metric names, as they only differ "+
+				"by '.' and '_'", strings.Join(names, "', '")))
+		}
+	}
+	sort.Strings(collisions)
+	return append(warns, collisions...), errors
+}
+
+// parseTopicsSpec reads the topics out of a YAML or JSON spec
+func parseTopicsSpec(spec string) ([]*topicSpec, error) {
+	parsedSpec := new(topicsSpec)
+	if err := yaml.UnmarshalStrict([]byte(spec), parsedSpec); err != nil {
+		return nil, fmt.Errorf("Unable to parse topics spec: %v", err)
+	}
+	names := map[string]bool{}
+	for _, topic := range parsedSpec.Topics {
+		if names[topic.Name] {
+			return nil, fmt.Errorf("Topic '%s' is declared more than "+
+				"once in the topics spec", topic.Name)
+		}
+		names[topic.Name] = true
+		if topic.Partitions == 0 {
+			topic.Partitions = defaultPartitionsCount
+		}
+		if topic.Config == nil {
+			topic.Config = map[string]string{}
+		}
+	}
+	return parsedSpec.Topics, nil
+}
+
+func readTopicSpec(ctx context.Context, client *kafkaRESTClient, name string) (*topicSpec, error) {
+	topic, err := client.ReadTopic(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	topicConfigs, err := client.ListTopicConfigs(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	configs := map[string]string{}
+	for _, topicConfig := range topicConfigs {
+		if topicConfig.Source == topicConfigSource && topicConfig.Value != nil {
+			configs[topicConfig.Name] = *topicConfig.Value
+		}
+	}
+	return &topicSpec{
+		Name:       topic.TopicName,
+		Partitions: topic.PartitionsCount,
+		Config:     configs,
+	}, nil
+}
+
+// updateTopicSpec increases the partitions of a topic if needed,
+// and sends all of its config changes in a single batch.
+func updateTopicSpec(ctx context.Context, client *kafkaRESTClient, currentTopic, desiredTopic *topicSpec) error {
+	if desiredTopic.Partitions > currentTopic.Partitions {
+		err := client.UpdatePartitionsCount(ctx, desiredTopic.Name, desiredTopic.Partitions)
+		if err != nil {
+			return err
+		}
+	}
+	currentConfigs := map[string]interface{}{}
+	for name, value := range currentTopic.Config {
+		currentConfigs[name] = value
+	}
+	desiredConfigs := map[string]interface{}{}
+	for name, value := range desiredTopic.Config {
+		desiredConfigs[name] = value
+	}
+	set, reset := diffStringMaps(currentConfigs, desiredConfigs)
+	if len(set) == 0 && len(reset) == 0 {
+		return nil
+	}
+	return client.AlterTopicConfigs(ctx, desiredTopic.Name, set, reset)
+}
+
+func expandTopicSpecs(topicSet *schema.Set) []*topicSpec {
+	topics := []*topicSpec{}
+	for _, item := range topicSet.List() {
+		topic := item.(map[string]interface{})
+		topics = append(topics, &topicSpec{
+			Name:       topic["name"].(string),
+			Partitions: topic["partitions_count"].(int),
+			Config:     expandStringMap(topic["config"].(map[string]interface{})),
+		})
+	}
+	return topics
+}
+
+func flattenTopicSpecs(topics []*topicSpec) []interface{} {
+	sort.Slice(topics, func(i, j int) bool {
+		return topics[i].Name < topics[j].Name
+	})
+	items := []interface{}{}
+	for _, topic := range topics {
+		items = append(items, map[string]interface{}{
+			"name":             topic.Name,
+			"partitions_count": topic.Partitions,
+			"config":           topic.Config,
+		})
+	}
+	return items
+}
+
+func topicError(name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("Topic '%s': %v", name, err)
+}
+
+// runConcurrently runs the tasks with at most concurrency of
+// them at a time, and reports every error that happened.
+func runConcurrently(concurrency int, tasks []func() error) error {
+	var waitGroup sync.WaitGroup
+	var mutex sync.Mutex
+	errors := []string{}
+	queue := make(chan func() error)
+	for worker := 0; worker < concurrency; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for task := range queue {
+				if err := task(); err != nil {
+					mutex.Lock()
+					errors = append(errors, err.Error())
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	for _, task := range tasks {
+		queue <- task
+	}
+	close(queue)
+	waitGroup.Wait()
+	if len(errors) > 0 {
+		sort.Strings(errors)
+		return fmt.Errorf("%d of %d operations failed:\n%s", len(errors),
+			len(tasks), strings.Join(errors, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCheckTopicSpecsCollisions(t *testing.T) {
+	topics, err := parseTopicsSpec(`
+topics:
+  - name: orders.v1
+  - name: orders_v1
+  - name: payments
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	warns, errors := checkTopicSpecs(topics)
+	if len(errors) > 0 {
+		t.Fatalf("Valid topics were rejected: %v", errors)
+	}
+	collisions := 0
+	for _, warn := range warns {
+		if strings.Contains(warn, "'orders.v1', 'orders_v1' collide") {
+			collisions++
+		}
+	}
+	if collisions != 1 {
+		t.Fatalf("Expected the collision of orders.v1 and orders_v1 to be "+
+			"reported once, but got %v", warns)
+	}
+	specWarns, specErrors := resourceKafkaTopics().Schema["spec"].ValidateFunc(`
+topics:
+  - name: orders.v1
+  - name: orders_v1
+`, "spec")
+	if len(specErrors) > 0 || len(specWarns) == 0 {
+		t.Fatalf("Expected the spec to be valid with warnings, but got %v and %v",
+			specWarns, specErrors)
+	}
+}
+
+func TestKafkaTopicsRejectsInvalidPartitions(t *testing.T) {
+	for _, partitions := range []string{"-3", "0"} {
+		config := map[string]interface{}{
+			"spec": "topics:\n  - name: orders\n    partitions: " + partitions + "\n",
+		}
+		_, err := resourceKafkaTopics().Diff(context.Background(), nil,
+			terraform.NewResourceConfigRaw(config), nil)
+		// Zero means the default number of partitions
+		if partitions == "0" {
+			if err != nil {
+				t.Errorf("Topic with default partitions was rejected: %v", err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), "needs at least 1") {
+			t.Errorf("Topic with %s partitions was not rejected: %v", partitions, err)
+		}
+	}
+}
+
+// trackedTopicNames lists the topics that the state of a
+// ccloud_kafka_topics resource considers its own.
+func trackedTopicNames(state *terraform.InstanceState) []string {
+	names := []string{}
+	if state == nil || state.ID == "" {
+		return names
+	}
+	data := resourceKafkaTopics().Data(state)
+	for _, topic := range expandTopicSpecs(data.Get("topic").(*schema.Set)) {
+		names = append(names, topic.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyConfigWithErrors is like applyConfig, but returns
+// the errors of the apply instead of failing the test.
+func applyConfigWithErrors(t *testing.T, resource *schema.Resource, state *terraform.InstanceState,
+	config map[string]interface{}, meta interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+	diff, err := resource.Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(config), meta)
+	if err != nil {
+		t.Fatalf("Unable to plan: %v", err)
+	}
+	return resource.Apply(context.Background(), state, diff, meta)
+}
+
+func destroy(t *testing.T, resource *schema.Resource, state *terraform.InstanceState, meta interface{}) {
+	t.Helper()
+	_, diags := resource.Apply(context.Background(), state,
+		&terraform.InstanceDiff{Destroy: true}, meta)
+	if diags.HasError() {
+		t.Fatalf("Unable to destroy: %v", diags)
+	}
+}
+
+func TestKafkaTopicsLifecycle(t *testing.T) {
+	fake := newFakeKafkaREST(t)
+	meta := &providerMeta{kafka: fake.kafkaSettings()}
+	topicsResource := resourceKafkaTopics()
+
+	// Create
+	config := map[string]interface{}{
+		"max_concurrency": 2,
+		"spec": `
+topics:
+  - name: orders
+    partitions: 3
+    config:
+      cleanup.policy: compact
+  - name: payments
+  - name: shipments
+`,
+	}
+	state := applyConfig(t, topicsResource, nil, config, meta)
+	if names := trackedTopicNames(state); !reflect.DeepEqual(names, []string{"orders", "payments", "shipments"}) {
+		t.Fatalf("Expected all topics of the spec to be tracked but got %v", names)
+	}
+	if topic := fake.topic("payments"); topic == nil || topic.partitionsCount != defaultPartitionsCount {
+		t.Fatalf("Topic without partitions was not created with the default: %+v", topic)
+	}
+
+	// Update: increase partitions, change configs, delete and add topics
+	config["spec"] = `
+topics:
+  - name: orders
+    partitions: 6
+    config:
+      retention.ms: "86400000"
+  - name: shipments
+  - name: refunds
+    partitions: 2
+`
+	state = applyConfig(t, topicsResource, state, config, meta)
+	orders := fake.topic("orders")
+	if orders.partitionsCount != 6 || !reflect.DeepEqual(orders.configs,
+		map[string]string{"retention.ms": "86400000"}) {
+		t.Fatalf("Topic was not updated as configured: %+v", orders)
+	}
+	if fake.topic("payments") != nil {
+		t.Fatal("Topic removed from the spec was not deleted")
+	}
+	if topic := fake.topic("refunds"); topic == nil || topic.partitionsCount != 2 {
+		t.Fatalf("Topic added to the spec was not created: %+v", topic)
+	}
+	if names := trackedTopicNames(state); !reflect.DeepEqual(names, []string{"orders", "refunds", "shipments"}) {
+		t.Fatalf("Expected the updated topics to be tracked but got %v", names)
+	}
+
+	// Delete
+	destroy(t, topicsResource, state, meta)
+	for _, name := range []string{"orders", "refunds", "shipments"} {
+		if fake.topic(name) != nil {
+			t.Errorf("Topic %s was not deleted", name)
+		}
+	}
+}
+
+// Topics that already exist on the cluster must never be taken over,
+// or destroying the resource would delete topics it didn't create.
+func TestKafkaTopicsPartialFailures(t *testing.T) {
+	fake := newFakeKafkaREST(t)
+	meta := &providerMeta{kafka: fake.kafkaSettings()}
+	topicsResource := resourceKafkaTopics()
+	fake.topics["existing"] = &fakeTopic{partitionsCount: 1, configs: map[string]string{}}
+	fake.topics["taken"] = &fakeTopic{partitionsCount: 1, configs: map[string]string{}}
+
+	config := map[string]interface{}{
+		"spec": "topics:\n  - name: existing\n  - name: fresh\n",
+	}
+	state, diags := applyConfigWithErrors(t, topicsResource, nil, config, meta)
+	if !diags.HasError() {
+		t.Fatal("Creating a topic that already exists didn't fail")
+	}
+	if names := trackedTopicNames(state); !reflect.DeepEqual(names, []string{"fresh"}) {
+		t.Fatalf("Expected only the created topic to be tracked but got %v", names)
+	}
+
+	config["spec"] = "topics:\n  - name: existing\n  - name: fresh\n  - name: taken\n"
+	state, diags = applyConfigWithErrors(t, topicsResource, state, config, meta)
+	if !diags.HasError() {
+		t.Fatal("Adding a topic that already exists didn't fail")
+	}
+	if names := trackedTopicNames(state); !reflect.DeepEqual(names, []string{"fresh"}) {
+		t.Fatalf("Expected only the created topic to be tracked but got %v", names)
+	}
+
+	destroy(t, topicsResource, state, meta)
+	if fake.topic("fresh") == nil && fake.topic("existing") != nil && fake.topic("taken") != nil {
+		return
+	}
+	t.Fatalf("Destroying deleted the wrong topics: existing=%v taken=%v fresh=%v",
+		fake.topic("existing") != nil, fake.topic("taken") != nil, fake.topic("fresh") != nil)
+}
+
+func TestKafkaTopicsNothingCreated(t *testing.T) {
+	fake := newFakeKafkaREST(t)
+	meta := &providerMeta{kafka: fake.kafkaSettings()}
+	fake.topics["existing"] = &fakeTopic{partitionsCount: 1, configs: map[string]string{}}
+	config := map[string]interface{}{
+		"spec": "topics:\n  - name: existing\n",
+	}
+	state, diags := applyConfigWithErrors(t, resourceKafkaTopics(), nil, config, meta)
+	if !diags.HasError() {
+		t.Fatal("Creating a topic that already exists didn't fail")
+	}
+	if state != nil && state.ID != "" {
+		t.Fatalf("Resource that created no topics was saved: %v", state)
+	}
+}